@@ -0,0 +1,161 @@
+package vuego_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestVBindSpread(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "spreads a map",
+			template: `<input v-bind="attrs">`,
+			data:     map[string]any{"attrs": map[string]any{"type": "text", "name": "q", "disabled": false, "required": true}},
+			want:     `<input name="q" required type="text">`,
+		},
+		{
+			name:     "merges with class and style",
+			template: `<div class="a" style="color: red; margin: 0" v-bind="attrs"></div>`,
+			data:     map[string]any{"attrs": map[string]string{"class": "b", "style": "color: blue", "id": "x"}},
+			want:     `<div class="a b" style="color:blue;margin:0;" id="x"></div>`,
+		},
+		{
+			name:     "later bindings win",
+			template: `<img v-bind="attrs" :src="link">`,
+			data:     map[string]any{"attrs": map[string]any{"src": "/a.png", "title": `"t"`}, "link": "/b.png"},
+			want:     `<img src="/b.png" title="&#34;t&#34;">`,
+		},
+		{
+			name:     "object literal and nil",
+			template: `<p v-bind="{id: 'p1'}"></p><p v-bind="missing"></p>`,
+			want:     `<p id="p1"></p><p></p>`,
+		},
+		{
+			name:     "values are escaped for their context",
+			template: `<a v-bind="attrs">x</a>`,
+			data:     map[string]any{"attrs": map[string]any{"href": "javascript:alert(1)"}},
+			want:     `<a href="#ZgotmplZ">x</a>`,
+		},
+		{
+			name:     "internal attribute names are dropped",
+			template: `<div v-bind="attrs"></div>`,
+			data:     map[string]any{"attrs": map[string]any{"data-v-html-content": "<script>alert(1)</script>", "DATA-V-TEXT-CONTENT": "x", "v-if": false, ":id": "x", "@click": "x", "v-once-id": "x", "id": "d"}},
+			want:     `<div id="d"></div>`,
+		},
+		{
+			name:     "invalid attribute names are dropped",
+			template: `<div v-bind="attrs"></div>`,
+			data:     map[string]any{"attrs": map[string]any{"x onmouseover=alert(1) y": "1", `a"b`: "1", "a>b": "1", "a/b": "1", "": "1", "data-ok": "1"}},
+			want:     `<div data-ok="1"></div>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"page.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+			tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestVBindSpread_NotObject(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<div v-bind="items"></div>`)},
+	}
+	tpl := vuego.NewFS(templateFS)
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Fill(map[string]any{"items": []string{"a"}}).Render(t.Context(), &buf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "v-bind expects an object, got []string")
+}
+
+func TestVBindSpread_InvalidNameStrict(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<div v-bind="attrs"></div>`)},
+	}
+	tpl := vuego.NewFS(templateFS, vuego.WithStrict())
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Fill(map[string]any{"attrs": map[string]any{"x onmouseover=alert(1) y": "1"}}).Render(t.Context(), &buf)
+	var strictErr *vuego.StrictError
+	assert.True(t, errors.As(err, &strictErr))
+	assert.Contains(t, err.Error(), `invalid attribute name "x onmouseover=alert(1) y"`)
+}
+
+func TestAttrsFallthrough(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"components/ButtonPrimary.vuego": &fstest.MapFile{Data: []byte(`<template :required="label"><button class="btn" style="color: red">{{ label }}</button></template>`)},
+		"components/Badge.vuego":         &fstest.MapFile{Data: []byte(`<span class="badge">{{ text }}</span>`)},
+		"components/Field.vuego":         &fstest.MapFile{Data: []byte(`<template :required="label" inherit-attrs="false"><label>{{ label }} <input v-bind="$attrs"></label></template>`)},
+		"components/Pair.vuego":          &fstest.MapFile{Data: []byte(`<template :required="a"><b>{{ a }}</b><i></i></template>`)},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "undeclared attributes fall through",
+			template: `<button-primary label="Save" class="x" style="color: blue" data-id="1" :title="tip"></button-primary>`,
+			data:     map[string]any{"tip": "Saves"},
+			want:     `<button class="btn x" style="color:blue;" data-id="1" title="Saves">Save</button>`,
+		},
+		{
+			name:     "without declared props only class, style, data and aria fall through",
+			template: `<badge text="New" class="new" aria-label="badge" data-id="2"></badge>`,
+			want:     `<span class="badge new" aria-label="badge" data-id="2">New</span>`,
+		},
+		{
+			name:     "spread internal attributes don't fall through",
+			template: `<badge text="New" v-bind="attrs"></badge>`,
+			data:     map[string]any{"attrs": map[string]any{"data-v-html-content": "<script>alert(1)</script>", "DATA-V-HTML-CONTENT": "<script>alert(1)</script>", "data-id": "3"}},
+			want:     `<span class="badge" data-id="3">New</span>`,
+		},
+		{
+			name:     "spread props",
+			template: `<button-primary v-bind="props"></button-primary>`,
+			data:     map[string]any{"props": map[string]any{"label": "Go", "type": "submit"}},
+			want:     `<button class="btn" style="color: red" type="submit">Go</button>`,
+		},
+		{
+			name:     "inherit-attrs false and $attrs",
+			template: `<field label="Name" name="q" placeholder="Search"></field>`,
+			want:     `<label>Name <input name="q" placeholder="Search"></label>`,
+		},
+		{
+			name:     "no single root element",
+			template: `<pair a="1" class="x"></pair>`,
+			want:     `<b>1</b><i></i>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS["page.vuego"] = &fstest.MapFile{Data: []byte(tc.template)}
+			tpl := vuego.NewFS(templateFS, vuego.WithComponents(), vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
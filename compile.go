@@ -0,0 +1,294 @@
+package vuego
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego/internal/helpers"
+	"github.com/titpetric/vuego/internal/parser"
+	"github.com/titpetric/vuego/internal/ulid"
+)
+
+// compileCacheLimit bounds the number of entries kept per parse cache.
+// Template sources are finite, so the limit only guards against callers
+// rendering unbounded dynamic template strings via RenderString.
+const compileCacheLimit = 8192
+
+// textSegment is one piece of a pre-parsed interpolation string.
+type textSegment struct {
+	text   string    // literal text written before the expression
+	expr   string    // trimmed expression between {{ and }}
+	pipe   *pipeExpr // parsed pipe for complex expressions, nil for simple paths
+	interp bool      // false for the trailing literal segment
+}
+
+// forLoop is a pre-parsed v-for expression.
+type forLoop struct {
+	vars       []string
+	collection string
+	err        error
+}
+
+// compileCache memoizes the parsed form of directive values and interpolations.
+// Keys are the raw attribute or text values, so identical expressions share one entry
+// across all templates of a Vue instance.
+type compileCache struct {
+	mu       sync.RWMutex
+	segments map[string][]textSegment
+	pipes    map[string]*pipeExpr
+	loops    map[string]*forLoop
+}
+
+func newCompileCache() *compileCache {
+	return &compileCache{
+		segments: make(map[string][]textSegment),
+		pipes:    make(map[string]*pipeExpr),
+		loops:    make(map[string]*forLoop),
+	}
+}
+
+// cachedParse returns m[key], computing and storing it with parse on a miss.
+func cachedParse[T any](mu *sync.RWMutex, m map[string]T, key string, parse func(string) T) T {
+	mu.RLock()
+	val, ok := m[key]
+	mu.RUnlock()
+	if ok {
+		return val
+	}
+
+	val = parse(key)
+
+	mu.Lock()
+	if len(m) < compileCacheLimit {
+		m[key] = val
+	}
+	mu.Unlock()
+	return val
+}
+
+// segments returns the pre-parsed interpolation segments for input.
+func (v *Vue) segments(input string) []textSegment {
+	return cachedParse(&v.compiled.mu, v.compiled.segments, input, parseSegments)
+}
+
+// pipe returns the pre-parsed pipe expression for expr.
+func (v *Vue) pipe(expr string) *pipeExpr {
+	return cachedParse(&v.compiled.mu, v.compiled.pipes, expr, func(s string) *pipeExpr {
+		p := parsePipeExpr(s)
+		return &p
+	})
+}
+
+// loop returns the pre-parsed v-for expression for expr.
+func (v *Vue) loop(expr string) *forLoop {
+	return cachedParse(&v.compiled.mu, v.compiled.loops, expr, func(s string) *forLoop {
+		vars, collection, err := parseFor(s)
+		return &forLoop{vars: vars, collection: collection, err: err}
+	})
+}
+
+// compiledTemplate holds what is derived from a compiled template DOM when
+// it is loaded. Like the DOM, it is shared between renders and read-only.
+type compiledTemplate struct {
+	// filename is the template the DOM was parsed from.
+	filename string
+	// positions maps the nodes of the DOM and its loop bodies to their source positions.
+	positions parser.Positions
+	// bodies maps v-for elements to their loop body, see newLoopBody.
+	bodies map[*html.Node]*html.Node
+}
+
+// newCompiledTemplate builds the loop bodies of a compiled DOM. It is called
+// once the DOM is final, after scoping, as the bodies are copies of it.
+func newCompiledTemplate(filename string, dom []*html.Node, positions parser.Positions) *compiledTemplate {
+	c := &compiledTemplate{
+		filename:  filename,
+		positions: positions,
+		bodies:    make(map[*html.Node]*html.Node),
+	}
+	for _, node := range dom {
+		c.addLoopBodies(node)
+	}
+	return c
+}
+
+// addLoopBodies adds the loop bodies of node and its descendants, innermost
+// first, so the copies of nested v-for elements share their loop bodies.
+func (c *compiledTemplate) addLoopBodies(node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		c.addLoopBodies(child)
+	}
+	if node.Type != html.ElementNode || !helpers.HasAttr(node, "v-for") {
+		return
+	}
+	body := newLoopBody(node)
+	c.bodies[node] = body
+	if pos, ok := c.positions[node]; ok {
+		c.positions[body] = pos
+	}
+	c.mirrorChildren(body, node)
+}
+
+// mirrorChildren gives the descendants of a copy the positions and loop
+// bodies of the nodes they were copied from.
+func (c *compiledTemplate) mirrorChildren(copied, node *html.Node) {
+	for cc, n := copied.FirstChild, node.FirstChild; cc != nil && n != nil; cc, n = cc.NextSibling, n.NextSibling {
+		if pos, ok := c.positions[n]; ok {
+			c.positions[cc] = pos
+		}
+		if body, ok := c.bodies[n]; ok {
+			c.bodies[cc] = body
+		}
+		c.mirrorChildren(cc, n)
+	}
+}
+
+// newLoopBody returns the element a v-for node renders for each item: a copy
+// of node without the loop attributes, with its own copy of the children so
+// their Parent is the element being evaluated.
+func newLoopBody(node *html.Node) *html.Node {
+	body := helpers.ShallowCloneWithAttrs(node)
+	helpers.RemoveAttr(body, "v-for")
+	// A v-else branch with v-for was selected by its chain already
+	helpers.RemoveAttr(body, "v-else")
+	helpers.RemoveAttr(body, "v-else-if")
+	helpers.RemoveAttr(body, "v-sort-by")
+	helpers.RemoveAttr(body, "v-order")
+	body.Parent = node.Parent
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		body.AppendChild(helpers.DeepCloneNode(c))
+	}
+	return body
+}
+
+// loopBody returns the loop body of a v-for node from the templates being
+// evaluated. Copies of the compiled DOM, made for node processors, get a new
+// loop body for each evaluation of the v-for.
+func (v *Vue) loopBody(ctx VueContext, node *html.Node) *html.Node {
+	for _, c := range slices.Backward(ctx.compiled) {
+		if body, ok := c.bodies[node]; ok {
+			return body
+		}
+	}
+	body := newLoopBody(node)
+	if ctx.sources != nil {
+		mapSources(ctx.sources, body, node)
+	}
+	return body
+}
+
+// parseSegments splits input on {{ }} pairs. Expressions that need the pipe
+// evaluator are parsed up front, simple paths are resolved from the stack.
+func parseSegments(input string) []textSegment {
+	var result []textSegment
+	last := 0
+
+	for {
+		start := strings.Index(input[last:], "{{")
+		if start < 0 {
+			break
+		}
+		start += last
+
+		end := strings.Index(input[start+2:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start + 2
+
+		expr := strings.Trim(input[start+2:end], " \t\n\r")
+		segment := textSegment{
+			text:   input[last:start],
+			expr:   expr,
+			interp: true,
+		}
+		if strings.ContainsAny(expr, "|(") || helpers.IsComplexExpr(expr) {
+			pipe := parsePipeExpr(expr)
+			segment.pipe = &pipe
+		}
+		result = append(result, segment)
+
+		last = end + 2
+	}
+
+	return append(result, textSegment{text: input[last:]})
+}
+
+// compile prepares a freshly parsed template DOM for repeated rendering.
+// Component shorthand tags are resolved into includes, v-once elements get
+// a stable identifier, and every directive and interpolation is parsed into
+// the compile cache. The compiled DOM is shared between renders and is
+// treated as read-only from here on.
+func (v *Vue) compile(dom []*html.Node) error {
+	if err := v.resolveComponentTags(dom); err != nil {
+		return err
+	}
+	for _, node := range dom {
+		v.compileNode(node)
+	}
+	return nil
+}
+
+func (v *Vue) compileNode(node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		if strings.Contains(node.Data, "{{") {
+			v.warmSegments(v.segments(node.Data))
+		}
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if helpers.HasAttr(node, "v-once") && !helpers.HasAttr(node, "v-once-id") {
+		helpers.SetAttr(node, "v-once-id", ulid.String())
+	}
+
+	// v-pre content is emitted verbatim
+	if helpers.HasAttr(node, "v-pre") {
+		return
+	}
+
+	for _, attr := range node.Attr {
+		val := strings.TrimSpace(attr.Val)
+		switch {
+		case attr.Key == "v-for":
+			v.loop(val)
+		case attr.Key == "v-if" || attr.Key == "v-else-if" || attr.Key == "v-show":
+			_, _ = v.exprEval.prepare(helpers.NormalizeComparisonOperators(val))
+		case attr.Key == "v-html" || attr.Key == "v-html-safe" || attr.Key == "v-text" || attr.Key == "v-bind" || attr.Key == "v-cache",
+			strings.HasPrefix(attr.Key, ":"),
+			strings.HasPrefix(attr.Key, "v-bind:"):
+			v.warmPipe(v.pipe(val))
+		case containsInterpolation(val):
+			v.warmSegments(v.segments(val))
+		}
+	}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		v.compileNode(c)
+	}
+}
+
+// warmSegments compiles the expression programs used by the given segments.
+func (v *Vue) warmSegments(segments []textSegment) {
+	for _, segment := range segments {
+		if segment.pipe != nil {
+			v.warmPipe(segment.pipe)
+		}
+	}
+}
+
+// warmPipe compiles the expression programs used by a pipe.
+// Compile errors are cached and reported when the expression is evaluated.
+func (v *Vue) warmPipe(pipe *pipeExpr) {
+	for _, segment := range pipe.segments {
+		if segment.typ == segmentExpr {
+			_, _ = v.exprEval.prepare(segment.expr)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package vuego_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestVue_Render_CompiledTemplateIsReused(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<h1 v-once>{{ title }}</h1>
+<ul>
+  <li v-for="item in items" :class="item.class">{{ item.name | upper }}</li>
+</ul>
+<template include="row.vuego" :title="title"></template>
+<template v-html="body"></template>`)},
+		"row.vuego": &fstest.MapFile{Data: []byte(`<p>{{ title }}</p>`)},
+	}
+
+	vue := vuego.NewVue(templateFS)
+
+	render := func(data map[string]any) string {
+		var buf bytes.Buffer
+		assert.NoError(t, vue.Render(t.Context(), &buf, "page.vuego", data))
+		return buf.String()
+	}
+
+	first := map[string]any{
+		"items": []map[string]any{{"name": "a", "class": "x"}},
+		"title": "first",
+		"body":  "<b>one</b>",
+	}
+	second := map[string]any{
+		"items": []map[string]any{{"name": "b", "class": "y"}, {"name": "c"}},
+		"title": "second",
+		"body":  "<i>two</i>",
+	}
+
+	want := `<h1>first</h1><ul><li class="x">A</li></ul><p>first</p><b>one</b>`
+	assert.EqualHTML(t, []byte(want), []byte(render(first)), nil, nil)
+
+	// A second render must not observe state left behind by the first one.
+	want = `<h1>second</h1><ul><li class="y">B</li><li>C</li></ul><p>second</p><i>two</i>`
+	assert.EqualHTML(t, []byte(want), []byte(render(second)), nil, nil)
+
+	want = `<h1>first</h1><ul><li class="x">A</li></ul><p>first</p><b>one</b>`
+	assert.EqualHTML(t, []byte(want), []byte(render(first)), nil, nil)
+}
+
+func TestVue_Render_CompiledTemplateConcurrent(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<div v-for="(i, n) in items" :data-i="i">{{ n }}</div>`)},
+	}
+
+	vue := vuego.NewVue(templateFS)
+	data := map[string]any{"items": []int{1, 2, 3}}
+
+	var want bytes.Buffer
+	assert.NoError(t, vue.Render(t.Context(), &want, "page.vuego", data))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			var got bytes.Buffer
+			assert.NoError(t, vue.Render(t.Context(), &got, "page.vuego", data))
+			assert.Equal(t, want.String(), got.String())
+		})
+	}
+	wg.Wait()
+}
@@ -48,20 +48,8 @@ func isLiteralAttr(key string) bool {
 }
 
 // escapeAttrValue escapes HTML special characters in attribute values.
-// It avoids double-escaping already-escaped HTML entities.
+// Attribute values in the evaluated DOM are never escaped, so each value is escaped exactly once.
 func escapeAttrValue(val string) string {
-	// Quick check: if the string contains &, check if it's an HTML entity reference
-	// If it is, it's likely already escaped and we shouldn't escape it again
-	if strings.Contains(val, "&") {
-		// Check for common HTML entity patterns like &amp; &quot; &#34; etc
-		// If we find them, assume it's already properly escaped
-		if strings.Contains(val, "&amp;") || strings.Contains(val, "&quot;") ||
-			strings.Contains(val, "&apos;") || strings.Contains(val, "&lt;") ||
-			strings.Contains(val, "&gt;") || strings.Contains(val, "&#") {
-			return val
-		}
-	}
-	// Otherwise, escape unescaped special characters
 	return html.EscapeString(val)
 }
 
@@ -73,10 +61,19 @@ func shouldIgnoreAttr(key string) bool {
 	}
 
 	switch key {
-	case "v-if", "v-keep", "v-else-if", "v-else", "v-for", "v-pre", "v-html", "v-text", "v-show", "v-once", "v-once-id", "data-v-html-content", "data-v-text-content":
+	case "v-if", "v-keep", "v-else-if", "v-else", "v-for", "v-pre", "v-html", "v-html-safe", "v-text", "v-show", "v-once", "v-cache":
 		return true
 	}
-	return false
+	return internalAttrs[key]
+}
+
+// internalAttrs are set by the evaluator to pass state to later passes and the
+// renderer, like evaluated v-html content. Template data can't set them.
+var internalAttrs = map[string]bool{
+	"v-once-id":           true,
+	"data-v-html-content": true,
+	"data-v-html-nodes":   true,
+	"data-v-text-content": true,
 }
 
 func renderAttrs(attrs []html.Attribute) string {
@@ -95,6 +92,10 @@ func renderAttrs(attrs []html.Attribute) string {
 
 		sb.WriteByte(' ')
 		sb.WriteString(key)
+		// Boolean attributes are true by presence, so they are written without a value
+		if helpers.IsBooleanAttr(key) && (a.Val == "" || strings.EqualFold(a.Val, key)) {
+			continue
+		}
 		sb.WriteByte('=')
 		sb.WriteByte('"')
 		sb.WriteString(escapeAttrValue(a.Val))
@@ -118,24 +119,59 @@ func getIndent(indent int) string {
 	return strings.Repeat(" ", indent)
 }
 
-// shouldEscapeTextNode checks if a text node needs HTML escaping.
-// Returns false if the text appears to be already HTML-escaped (from interpolation),
-// true if it contains raw HTML special characters that need escaping.
-func shouldEscapeTextNode(data string) bool {
-	// If the text contains HTML entity references like &lt; &amp; &#39; etc,
-	// it's likely from interpolation and already escaped
-	if strings.Contains(data, "&") && strings.Contains(data, ";") {
-		return false
+// escapeText escapes text node data, unless it is the content of a raw text
+// element like <script>. Text nodes are never escaped in the evaluated DOM, so
+// they are escaped exactly once, and trusted HTML is carried in raw nodes instead.
+func escapeText(data string, raw bool) string {
+	if raw {
+		return data
 	}
-	// Check if text contains unescaped HTML special characters
-	return strings.ContainsAny(data, "<>&\"'")
+	return html.EscapeString(data)
+}
+
+// isInlineContent reports whether node only contains text, which is rendered
+// on the same line as the tags around it.
+func isInlineContent(node *html.Node) bool {
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.TextNode && c.Type != html.RawNode {
+			return false
+		}
+	}
+	return node.FirstChild != nil
 }
 
 func renderNode(w io.Writer, node *html.Node, indent int) error {
 	ctx := VueContext{}
+	if _, ok := w.(*errWriter); !ok {
+		w = &errWriter{w: w}
+	}
 	return renderNodeWithContext(ctx, w, node, indent)
 }
 
+// errWriter keeps the first error returned by w and skips later writes,
+// so rendering stops at the first failed write, e.g. an exceeded output limit.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(p)
+	e.err = err
+	return n, err
+}
+
+// writeErr returns the error of a failed write to w, if w is an errWriter.
+func writeErr(w io.Writer) error {
+	if ew, ok := w.(*errWriter); ok {
+		return ew.err
+	}
+	return nil
+}
+
 func renderNodeWithContext(ctx VueContext, w io.Writer, node *html.Node, indent int) error {
 	switch node.Type {
 	case html.TextNode:
@@ -143,15 +179,14 @@ func renderNodeWithContext(ctx VueContext, w io.Writer, node *html.Node, indent
 			return nil
 		}
 		spaces := getIndent(indent)
-		parentTag := ctx.CurrentTag()
 		// Skip HTML escaping inside script and style tags
-		if parentTag == "script" || parentTag == "style" {
-			_, _ = w.Write([]byte(spaces + node.Data))
-		} else if shouldEscapeTextNode(node.Data) {
-			_, _ = w.Write([]byte(spaces + html.EscapeString(node.Data)))
-		} else {
-			_, _ = w.Write([]byte(spaces + node.Data))
+		_, _ = w.Write([]byte(spaces + escapeText(node.Data, helpers.IsRawTextElement(ctx.CurrentTag()))))
+
+	case html.RawNode:
+		if strings.TrimSpace(node.Data) == "" {
+			return nil
 		}
+		_, _ = w.Write([]byte(getIndent(indent) + node.Data))
 
 	case html.ElementNode:
 		// Count children without allocating slice
@@ -164,27 +199,37 @@ func renderNodeWithContext(ctx VueContext, w io.Writer, node *html.Node, indent
 		spaces := getIndent(indent)
 		tagName := node.Data
 
-		// Check if this element has evaluated v-html or v-text content (stored in internal attributes)
-		var vhtmlContent string
-		var vtextContent string
-		for _, attr := range node.Attr {
-			if attr.Key == "data-v-html-content" {
-				vhtmlContent = attr.Val
-				break
-			}
-			if attr.Key == "data-v-text-content" {
-				vtextContent = attr.Val
-				break
+		// If this element has v-html or v-text content, output it directly without indentation
+		if content := evaluatedContent(node); content != "" {
+			// Special case for <template>: output content only, not the template tags
+			if tagName == "template" {
+				_, _ = w.Write([]byte(content))
+			} else {
+				_, _ = w.Write([]byte(spaces + "<" + tagName + renderAttrs(node.Attr) + ">"))
+				_, _ = w.Write([]byte(content))
+				_, _ = w.Write([]byte("</" + tagName + ">\n"))
 			}
+			return writeErr(w)
 		}
 
-		// If this element has v-html or v-text content, output it directly without indentation
-		if vhtmlContent != "" || vtextContent != "" {
-			content := vhtmlContent
-			if vtextContent != "" {
-				content = vtextContent
+		// Spliced v-html nodes (layout content) are written inline, like v-html strings.
+		// Their whitespace is kept; only the line break this renderer adds after the
+		// last element is dropped, so the closing tag follows the content directly.
+		if helpers.HasAttr(node, "data-v-html-nodes") {
+			var sb strings.Builder
+			for c := firstChild; c != nil; c = c.NextSibling {
+				if helpers.IsPreformattedElement(tagName) {
+					renderVerbatim(&sb, c, helpers.IsRawTextElement(tagName))
+					continue
+				}
+				if err := renderNodeWithContext(ctx, &sb, c, 0); err != nil {
+					return err
+				}
+			}
+			content := sb.String()
+			if !helpers.IsPreformattedElement(tagName) && endsWithElement(node) {
+				content = strings.TrimSuffix(content, "\n")
 			}
-			// Special case for <template>: output content only, not the template tags
 			if tagName == "template" {
 				_, _ = w.Write([]byte(content))
 			} else {
@@ -192,7 +237,7 @@ func renderNodeWithContext(ctx VueContext, w io.Writer, node *html.Node, indent
 				_, _ = w.Write([]byte(content))
 				_, _ = w.Write([]byte("</" + tagName + ">\n"))
 			}
-			return nil
+			return writeErr(w)
 		}
 
 		// Special handling for <template> elements without v-html: output children without template tag (unless v-keep is set)
@@ -202,7 +247,7 @@ func renderNodeWithContext(ctx VueContext, w io.Writer, node *html.Node, indent
 					return err
 				}
 			}
-			return nil
+			return writeErr(w)
 		}
 
 		// If template has v-keep, render it with v-keep removed from attributes
@@ -228,21 +273,41 @@ func renderNodeWithContext(ctx VueContext, w io.Writer, node *html.Node, indent
 			ctx.PopTag()
 
 			_, _ = w.Write([]byte(spaces + "</" + tagName + ">\n"))
-			return nil
+			return writeErr(w)
+		}
+
+		// Void elements have no end tag and no content
+		if helpers.IsVoidElement(tagName) {
+			_, _ = w.Write([]byte(spaces + "<" + tagName + renderAttrs(node.Attr) + ">\n"))
+			return writeErr(w)
+		}
+
+		// Whitespace is significant in preformatted elements, so their content is written verbatim
+		if helpers.IsPreformattedElement(tagName) {
+			_, _ = w.Write([]byte(spaces + "<" + tagName + renderAttrs(node.Attr) + ">"))
+			// The parser drops a newline directly after the start tag, write it back so it isn't lost
+			if tagName == "pre" || tagName == "textarea" || tagName == "listing" {
+				if firstChild != nil && firstChild.Type == html.TextNode && strings.HasPrefix(firstChild.Data, "\n") {
+					_, _ = w.Write([]byte("\n"))
+				}
+			}
+			raw := helpers.IsRawTextElement(tagName)
+			for c := firstChild; c != nil; c = c.NextSibling {
+				renderVerbatim(w, c, raw)
+			}
+			_, _ = w.Write([]byte("</" + tagName + ">\n"))
+			return writeErr(w)
 		}
 
 		// compact single-entry text nodes
 		if childCount == 0 {
 			_, _ = w.Write([]byte(spaces + "<" + tagName + renderAttrs(node.Attr) + "></" + tagName + ">\n"))
-		} else if childCount == 1 && firstChild.Type == html.TextNode {
+		} else if isInlineContent(node) {
 			_, _ = w.Write([]byte(spaces + "<" + tagName + renderAttrs(node.Attr) + ">"))
 			// Skip HTML escaping inside script and style tags
-			if tagName == "script" || tagName == "style" {
-				_, _ = w.Write([]byte(firstChild.Data))
-			} else if shouldEscapeTextNode(firstChild.Data) {
-				_, _ = w.Write([]byte(html.EscapeString(firstChild.Data)))
-			} else {
-				_, _ = w.Write([]byte(firstChild.Data))
+			raw := helpers.IsRawTextElement(tagName)
+			for c := firstChild; c != nil; c = c.NextSibling {
+				renderVerbatim(w, c, raw)
 			}
 			_, _ = w.Write([]byte("</" + tagName + ">\n"))
 		} else {
@@ -259,5 +324,64 @@ func renderNodeWithContext(ctx VueContext, w io.Writer, node *html.Node, indent
 		}
 	}
 
-	return nil
+	return writeErr(w)
+}
+
+// endsWithElement reports whether the last rendered child of node is an element.
+// Whitespace-only text is skipped, as the indenting renderer doesn't write it.
+func endsWithElement(node *html.Node) bool {
+	result := false
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode && strings.TrimSpace(c.Data) == "" {
+			continue
+		}
+		result = c.Type == html.ElementNode
+	}
+	return result
+}
+
+// renderVerbatim writes node without indentation or whitespace changes.
+// Text is escaped unless raw is set, as for the content of script and style.
+func renderVerbatim(w io.Writer, node *html.Node, raw bool) {
+	switch node.Type {
+	case html.TextNode:
+		_, _ = w.Write([]byte(escapeText(node.Data, raw)))
+	case html.RawNode:
+		_, _ = w.Write([]byte(node.Data))
+	case html.ElementNode:
+		if node.Data == "template" && !helpers.HasAttr(node, "v-keep") {
+			if content := evaluatedContent(node); content != "" {
+				_, _ = w.Write([]byte(content))
+				return
+			}
+			for c := node.FirstChild; c != nil; c = c.NextSibling {
+				renderVerbatim(w, c, raw)
+			}
+			return
+		}
+
+		_, _ = w.Write([]byte("<" + node.Data + renderAttrs(node.Attr) + ">"))
+		if helpers.IsVoidElement(node.Data) {
+			return
+		}
+		if content := evaluatedContent(node); content != "" {
+			_, _ = w.Write([]byte(content))
+		} else {
+			for c := node.FirstChild; c != nil; c = c.NextSibling {
+				renderVerbatim(w, c, raw)
+			}
+		}
+		_, _ = w.Write([]byte("</" + node.Data + ">"))
+	}
+}
+
+// evaluatedContent returns the evaluated v-html or v-text content of an element,
+// which is stored in internal attributes until the element is rendered.
+func evaluatedContent(node *html.Node) string {
+	for _, attr := range node.Attr {
+		if attr.Key == "data-v-html-content" || attr.Key == "data-v-text-content" {
+			return attr.Val
+		}
+	}
+	return ""
 }
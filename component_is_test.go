@@ -0,0 +1,78 @@
+package vuego_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestDynamicComponent(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"components/Hero.vuego":        &fstest.MapFile{Data: []byte(`<h1>{{ title }}</h1>`)},
+		"components/TextBlock.vuego":   &fstest.MapFile{Data: []byte(`<p :class="tone"><slot>empty</slot></p>`)},
+		"blocks/quote.vuego":           &fstest.MapFile{Data: []byte(`<blockquote>{{ text }}</blockquote>`)},
+		"components/CardWrapper.vuego": &fstest.MapFile{Data: []byte(`<div class="card"><slot name="header"></slot><slot></slot></div>`)},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "blocks in v-for",
+			template: `<main><component v-for="block in blocks" :is="block.type" :title="block.title" :text="block.text"></component></main>`,
+			data: map[string]any{"blocks": []map[string]any{
+				{"type": "hero", "title": "Welcome"},
+				{"type": "blocks/quote.vuego", "text": "Be brief."},
+			}},
+			want: `<main><h1>Welcome</h1><blockquote>Be brief.</blockquote></main>`,
+		},
+		{
+			name:     "static is and slots",
+			template: `<component is="card-wrapper"><template #header><h2>{{ heading }}</h2></template>Body</component>`,
+			data:     map[string]any{"heading": "Title"},
+			want:     `<div class="card"><h2>Title</h2>Body</div>`,
+		},
+		{
+			name:     "default slot and props",
+			template: `<component :is="kind" tone="muted">Hello</component><component :is="kind"></component>`,
+			data:     map[string]any{"kind": "text-block"},
+			want:     `<p class="muted">Hello</p><p>empty</p>`,
+		},
+		{
+			name:     "conditional",
+			template: `<component v-if="false" is="hero"></component><component v-else :is="kind" title="Else"></component>`,
+			data:     map[string]any{"kind": "hero"},
+			want:     `<h1>Else</h1>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS["page.vuego"] = &fstest.MapFile{Data: []byte(tc.template)}
+			tpl := vuego.NewFS(templateFS, vuego.WithComponents(), vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestDynamicComponent_Unknown(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte("<div>\n  <component :is=\"kind\"></component>\n</div>")},
+	}
+	tpl := vuego.NewFS(templateFS)
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Fill(map[string]any{"kind": "missing"}).Render(t.Context(), &buf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "page.vuego:2:3")
+	assert.Contains(t, err.Error(), "unknown component 'missing'")
+}
@@ -1,6 +1,7 @@
 package vuego_test
 
 import (
+	"bytes"
 	"testing"
 	"testing/fstest"
 
@@ -91,3 +92,65 @@ func TestComponent_LoadFragment(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestRenderer_HTML5(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		expected string
+	}{
+		{
+			name:     "void elements have no end tag",
+			template: `<p>a<br>b<img src="x.png"><input type="text"></p>`,
+			expected: "<p>\n  a  <br>\n  b  <img src=\"x.png\">\n  <input type=\"text\">\n</p>\n",
+		},
+		{
+			name:     "pre content is verbatim",
+			template: "<div><pre>  line 1\n    <b>{{ name }}</b>\n</pre></div>",
+			data:     map[string]any{"name": "x < y"},
+			expected: "<div>\n  <pre>  line 1\n    <b>x &lt; y</b>\n</pre>\n</div>\n",
+		},
+		{
+			name:     "textarea keeps whitespace",
+			template: "<textarea>\n\n  {{ body }}  </textarea>",
+			data:     map[string]any{"body": "hi"},
+			expected: "<textarea>\n\n  hi  </textarea>\n",
+		},
+		{
+			name:     "script is not escaped or indented",
+			template: "<div><script>\nif (a < b) {\n  run()\n}\n</script></div>",
+			expected: "<div>\n  <script>\nif (a < b) {\n  run()\n}\n</script>\n</div>\n",
+		},
+		{
+			name:     "boolean attributes",
+			template: `<input disabled="disabled" :checked="done" :required="optional" :readonly="0">`,
+			data:     map[string]any{"done": true, "optional": false},
+			expected: "<input disabled checked>\n",
+		},
+		{
+			name:     "zero and empty values are kept",
+			template: `<input :value="count" :placeholder="hint" :title="missing" :data-x="no">`,
+			data:     map[string]any{"count": 0, "hint": "", "no": false},
+			expected: "<input value=\"0\" placeholder=\"\">\n",
+		},
+		{
+			name:     "literal values",
+			template: `<input :value="0" :placeholder="''" :disabled="true" :checked="false" :title="'a' + 'b'" :maxlength="-1">`,
+			expected: "<input value=\"0\" placeholder=\"\" disabled title=\"ab\" maxlength=\"-1\">\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"test.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+
+			var buf bytes.Buffer
+			err := vuego.NewVue(templateFS).RenderFragment(t.Context(), &buf, "test.vuego", tc.data)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
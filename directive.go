@@ -0,0 +1,181 @@
+package vuego
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego/internal/helpers"
+)
+
+// Directive implements a custom directive like `v-permission="'admin'"`.
+//
+// Directives are applied to an element after its attributes are evaluated and
+// before its children are, in the order the directive attributes appear. The
+// directive attributes are removed from the output.
+//
+// On templates, includes and components, directives are applied before the
+// element is evaluated. They receive the element as written, and the attributes
+// they leave are evaluated as props.
+type Directive interface {
+	// Apply receives the element with a copy of its template children, and may
+	// modify its attributes and children in place. The children are evaluated
+	// after all directives are applied. Returning false drops the element from
+	// the output without evaluating its children.
+	Apply(ctx *VueContext, node *html.Node, binding DirectiveBinding) (bool, error)
+}
+
+// DirectiveFunc is an adapter to allow the use of ordinary functions as directives.
+type DirectiveFunc func(ctx *VueContext, node *html.Node, binding DirectiveBinding) (bool, error)
+
+// Apply calls f(ctx, node, binding).
+func (f DirectiveFunc) Apply(ctx *VueContext, node *html.Node, binding DirectiveBinding) (bool, error) {
+	return f(ctx, node, binding)
+}
+
+// DirectiveBinding describes a directive attribute on an element.
+type DirectiveBinding struct {
+	// Name is the directive name without the v- prefix, e.g. `tooltip`.
+	Name string
+	// Arg is the argument after the colon, e.g. `top` for `v-tooltip:top`.
+	Arg string
+	// Expression is the attribute value as written in the template.
+	Expression string
+	// Value is the evaluated expression, nil if the attribute has no value.
+	Value any
+}
+
+// RegisterDirective registers a custom directive. The name may be given with
+// or without the v- prefix; `permission` handles `v-permission` attributes.
+func (v *Vue) RegisterDirective(name string, d Directive) *Vue {
+	if v.directives == nil {
+		v.directives = make(map[string]Directive)
+	}
+	v.directives[strings.TrimPrefix(name, "v-")] = d
+	return v
+}
+
+// WithDirective returns a LoadOption that registers a custom directive, see RegisterDirective.
+func WithDirective(name string, d Directive) LoadOption {
+	return func(vue *Vue) {
+		vue.RegisterDirective(name, d)
+	}
+}
+
+// hasDirective reports whether node has an attribute of a registered custom directive.
+func (v *Vue) hasDirective(node *html.Node) bool {
+	if len(v.directives) == 0 {
+		return false
+	}
+	for _, attr := range node.Attr {
+		if strings.HasPrefix(attr.Key, "v-") {
+			name, _, _ := strings.Cut(attr.Key[2:], ":")
+			if _, ok := v.directives[name]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyElementDirectives applies the custom directives of an element before its
+// children are evaluated. The directives receive newNode, the element with its
+// attributes evaluated, and with a copy of the template children of node if
+// withChildren is set. It returns the node whose children are to be evaluated,
+// and false if a directive dropped the element.
+func (v *Vue) applyElementDirectives(ctx VueContext, node, newNode *html.Node, withChildren bool) (*html.Node, bool, error) {
+	if !v.hasDirective(newNode) {
+		return node, true, nil
+	}
+	if withChildren {
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			newNode.AppendChild(helpers.DeepCloneNode(c))
+		}
+	}
+	keep, err := v.applyDirectives(ctx, newNode)
+	return newNode, keep, err
+}
+
+// applyTagDirectives applies the custom directives of a template or component
+// element before it is evaluated. It returns a copy of node without the
+// directive attributes, or nil if a directive dropped the element.
+func (v *Vue) applyTagDirectives(ctx VueContext, node *html.Node) (*html.Node, error) {
+	if !v.hasDirective(node) {
+		return node, nil
+	}
+	clone := helpers.DeepCloneNode(node)
+	keep, err := v.applyDirectives(ctx, clone)
+	if err != nil || !keep {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// applyDirectives applies the custom directives of an element and removes
+// their attributes. It returns false if a directive dropped the element.
+func (v *Vue) applyDirectives(ctx VueContext, node *html.Node) (bool, error) {
+	if len(v.directives) == 0 {
+		return true, nil
+	}
+
+	type pending struct {
+		key       string
+		directive Directive
+		binding   DirectiveBinding
+	}
+	var found []pending
+	attrs := node.Attr[:0]
+	for _, attr := range node.Attr {
+		if strings.HasPrefix(attr.Key, "v-") {
+			name, arg, _ := strings.Cut(attr.Key[2:], ":")
+			if d, ok := v.directives[name]; ok {
+				found = append(found, pending{
+					key:       attr.Key,
+					directive: d,
+					binding:   DirectiveBinding{Name: name, Arg: arg, Expression: strings.TrimSpace(attr.Val)},
+				})
+				continue
+			}
+		}
+		attrs = append(attrs, attr)
+	}
+	node.Attr = attrs
+
+	for _, p := range found {
+		value, err := v.evalDirectiveValue(ctx, p.binding.Expression)
+		if err != nil {
+			return false, &attrError{key: p.key, name: p.key, err: err}
+		}
+		p.binding.Value = value
+
+		keep, err := p.directive.Apply(&ctx, node, p.binding)
+		if err != nil {
+			return false, &attrError{key: p.key, name: p.key, err: err}
+		}
+		if !keep {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// evalDirectiveValue evaluates a directive expression. Like template bindings,
+// it supports filters, variables and expr expressions such as literals.
+func (v *Vue) evalDirectiveValue(ctx VueContext, expr string) (any, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	if strings.Contains(expr, "|") || helpers.IsFunctionCall(expr) {
+		return v.evalPipe(ctx, *v.pipe(expr))
+	}
+	if val, ok := ctx.stack.Resolve(expr); ok {
+		return val, nil
+	}
+
+	val, err := v.evalExpr(ctx, expr)
+	if err != nil && !isFatalError(err) {
+		// Undefined variables evaluate to nil, as in bound attributes
+		return nil, nil
+	}
+	return val, err
+}
@@ -0,0 +1,145 @@
+package vuego_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+var testDirectives = []vuego.LoadOption{
+	vuego.WithDirective("permission", vuego.DirectiveFunc(func(ctx *vuego.VueContext, node *html.Node, binding vuego.DirectiveBinding) (bool, error) {
+		role, _ := ctx.Stack().Resolve("role")
+		return role == binding.Value, nil
+	})),
+	vuego.WithDirective("v-tooltip", vuego.DirectiveFunc(func(ctx *vuego.VueContext, node *html.Node, binding vuego.DirectiveBinding) (bool, error) {
+		placement := binding.Arg
+		if placement == "" {
+			placement = "bottom"
+		}
+		node.Attr = append(node.Attr,
+			html.Attribute{Key: "data-tooltip", Val: fmt.Sprint(binding.Value)},
+			html.Attribute{Key: "data-placement", Val: placement},
+		)
+		return true, nil
+	})),
+	vuego.WithDirective("placeholder", vuego.DirectiveFunc(func(ctx *vuego.VueContext, node *html.Node, binding vuego.DirectiveBinding) (bool, error) {
+		if node.FirstChild == nil {
+			node.AppendChild(&html.Node{Type: html.TextNode, Data: binding.Expression})
+		}
+		return true, nil
+	})),
+	vuego.WithDirective("fail", vuego.DirectiveFunc(func(ctx *vuego.VueContext, node *html.Node, binding vuego.DirectiveBinding) (bool, error) {
+		return false, errors.New("directive failed")
+	})),
+}
+
+func TestDirective(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "drops the node",
+			template: `<div><button v-permission="'admin'">Delete</button><span>ok</span></div>`,
+			data:     map[string]any{"role": "editor"},
+			want:     `<div><span>ok</span></div>`,
+		},
+		{
+			name:     "keeps the node without the attribute",
+			template: `<button v-permission="'admin'" class="btn">Delete</button>`,
+			data:     map[string]any{"role": "admin"},
+			want:     `<button class="btn">Delete</button>`,
+		},
+		{
+			name:     "modifies attributes with arg and evaluated value",
+			template: `<a v-tooltip:top="help.text">?</a><a v-tooltip="'Hi ' + name">?</a>`,
+			data:     map[string]any{"help": map[string]any{"text": "Help"}, "name": "Ana"},
+			want:     `<a data-tooltip="Help" data-placement="top">?</a><a data-tooltip="Hi Ana" data-placement="bottom">?</a>`,
+		},
+		{
+			name:     "replaces children",
+			template: `<p v-placeholder="Nothing here"></p><p v-placeholder="Unused">{{ text }}</p>`,
+			data:     map[string]any{"text": "Text"},
+			want:     `<p>Nothing here</p><p>Text</p>`,
+		},
+		{
+			name:     "applies to loop items and conditional branches",
+			template: `<ul><li v-for="item in items" v-permission="item.role">{{ item.name }}</li></ul><p v-if="false">a</p><p v-else v-permission="'owner'">b</p>`,
+			data: map[string]any{"role": "admin", "items": []map[string]any{
+				{"name": "a", "role": "admin"},
+				{"name": "b", "role": "editor"},
+			}},
+			want: `<ul><li>a</li></ul>`,
+		},
+		{
+			name:     "unregistered directives are kept",
+			template: `<p v-unknown="x">a</p>`,
+			want:     `<p v-unknown="x">a</p>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"page.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+			tpl := vuego.NewFS(templateFS, append(testDirectives, vuego.WithRenderer(vuego.NewFaithfulRenderer()))...)
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestDirective_Error(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte("<div>\n  <p v-fail>a</p>\n</div>")},
+	}
+	tpl := vuego.NewFS(templateFS, testDirectives...)
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Render(t.Context(), &buf)
+	assert.Error(t, err)
+	assert.Equal(t, "page.vuego:2:6: error evaluating attr v-fail: directive failed", err.Error())
+}
+
+func TestDirective_BeforeEvaluation(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"card.vuego": &fstest.MapFile{Data: []byte(`<div class="card">{{ title }}</div>`)},
+		"page.vuego": &fstest.MapFile{Data: []byte(`<section v-permission="'admin'"><p>{{ count() }}</p></section>` +
+			`<template include="card.vuego" title="Admin" v-permission="'admin'"></template>` +
+			`<template include="card.vuego" title="Editor" v-permission="'editor'"></template>` +
+			`<template v-permission="'admin'"><p>{{ count() }}</p></template>` +
+			`<x-badge v-permission="'admin'"></x-badge><x-badge v-permission="'editor'"></x-badge>`)},
+	}
+
+	calls := 0
+	badge := vuego.HTMLComponent(func(ctx *vuego.VueContext, props map[string]any, slots *vuego.SlotScope) ([]byte, error) {
+		return []byte(fmt.Sprintf("<b>%d</b>", len(props))), nil
+	})
+	opts := append(testDirectives, vuego.WithGoComponent("x-badge", badge), vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+	tpl := vuego.NewFS(templateFS, opts...)
+
+	data := map[string]any{
+		"role": "editor",
+		"count": func() int {
+			calls++
+			return calls
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf))
+	assert.Equal(t, `<div class="card">Editor</div><b>0</b>`, buf.String())
+	assert.Equal(t, 0, calls)
+}
@@ -0,0 +1,390 @@
+package vuego
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// escapeContext is the output context of an interpolated or bound value.
+// Values are escaped for the context they end up in, in the spirit of html/template.
+type escapeContext uint8
+
+const (
+	// contextHTML is element content and plain attribute values.
+	// Values are left for the renderer to HTML-escape.
+	contextHTML escapeContext = iota
+	// contextJS is <script> content and on* event handler attributes.
+	contextJS
+	// contextCSS is <style> content and style attributes.
+	contextCSS
+	// contextURL is a URL attribute like href or src.
+	contextURL
+	// contextSrcset is a srcset attribute, a list of URLs with size descriptors.
+	contextSrcset
+	// contextSrcdoc is an iframe srcdoc attribute, a complete HTML document.
+	contextSrcdoc
+)
+
+// unsafeReplacement replaces values that can't be made safe in their context,
+// like a javascript: URL. It is the marker html/template uses, so it is easy to search for.
+const unsafeReplacement = "#ZgotmplZ"
+
+// urlAttrs are attributes that hold a URL.
+var urlAttrs = map[string]bool{
+	"action": true, "background": true, "cite": true, "data": true, "formaction": true,
+	"href": true, "icon": true, "longdesc": true, "manifest": true, "poster": true,
+	"src": true, "usemap": true, "xlink:href": true,
+}
+
+// textContext returns the escape context for text inside the given element.
+func textContext(tag string) escapeContext {
+	switch tag {
+	case "script":
+		return contextJS
+	case "style":
+		return contextCSS
+	}
+	return contextHTML
+}
+
+// attrContext returns the escape context for the value of the given attribute.
+func attrContext(name string) escapeContext {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(name, "on"):
+		return contextJS
+	case name == "style":
+		return contextCSS
+	case urlAttrs[name]:
+		return contextURL
+	case name == "srcset" || name == "imagesrcset":
+		return contextSrcset
+	case name == "srcdoc":
+		return contextSrcdoc
+	}
+	return contextHTML
+}
+
+// escaper escapes values for one output context. Literal template text around the
+// values is fed to it, so it can tell if a value is inside a JS or CSS string, or
+// in the query part of a URL.
+type escaper struct {
+	context escapeContext
+
+	// quote is the open string delimiter in JS or CSS, or 0
+	quote byte
+	// comment is '/' or '*' inside a line or block comment
+	comment byte
+	// escaped is set after a backslash inside a string
+	escaped bool
+	// last is the previous literal byte, to find comment starts
+	last byte
+
+	// url tracks where a value lands in a URL
+	url urlPart
+}
+
+type urlPart uint8
+
+const (
+	urlStart urlPart = iota
+	urlPath
+	urlQuery
+)
+
+func newEscaper(context escapeContext) *escaper {
+	return &escaper{context: context}
+}
+
+// literal advances the escaper over literal template text.
+func (e *escaper) literal(text string) {
+	switch e.context {
+	case contextJS, contextCSS:
+		for i := 0; i < len(text); i++ {
+			e.scan(text[i])
+		}
+	case contextURL:
+		if e.url != urlQuery && strings.ContainsAny(text, "?#") {
+			e.url = urlQuery
+		} else if e.url == urlStart && strings.TrimSpace(text) != "" {
+			e.url = urlPath
+		}
+	}
+}
+
+// scan tracks strings and comments in JS and CSS one byte at a time.
+func (e *escaper) scan(c byte) {
+	last := e.last
+	e.last = c
+
+	switch {
+	case e.comment == '/':
+		if c == '\n' {
+			e.comment = 0
+		}
+	case e.comment == '*':
+		if last == '*' && c == '/' {
+			e.comment = 0
+			e.last = 0
+		}
+	case e.quote != 0:
+		switch {
+		case e.escaped:
+			e.escaped = false
+		case c == '\\':
+			e.escaped = true
+		case c == e.quote:
+			e.quote = 0
+		}
+	case c == '"' || c == '\'' || (c == '`' && e.context == contextJS):
+		e.quote = c
+	case last == '/' && c == '*':
+		e.comment = '*'
+		e.last = 0
+	case last == '/' && c == '/' && e.context == contextJS:
+		e.comment = '/'
+	}
+}
+
+// formatValue returns the text of an output value. Values implementing
+// fmt.Stringer or encoding.TextMarshaler are formatted through them.
+func formatValue(val any) string {
+	switch val := val.(type) {
+	case string:
+		return val
+	case fmt.Stringer:
+		return val.String()
+	case encoding.TextMarshaler:
+		if text, err := val.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(val)
+}
+
+// value returns val escaped for the current position in the output.
+func (e *escaper) value(val any) string {
+	if s, ok := trustedCode(e.context, val); ok && e.quote == 0 {
+		return s
+	}
+	switch e.context {
+	case contextJS:
+		if e.quote != 0 {
+			return escapeJSString(formatValue(val))
+		}
+		return escapeJSValue(val)
+	case contextCSS:
+		if e.quote != 0 {
+			return escapeCSSString(formatValue(val))
+		}
+		return filterCSSValue(formatValue(val))
+	case contextURL:
+		s := formatValue(val)
+		part := e.url
+		e.url = max(e.url, urlPath)
+		if _, ok := val.(URL); ok {
+			return normalizeURL(s)
+		}
+		switch part {
+		case urlStart:
+			return normalizeURL(filterURL(s))
+		case urlPath:
+			return normalizeURL(s)
+		}
+		return escapeURLComponent(s)
+	case contextSrcset, contextSrcdoc:
+		return escapeBoundAttr(e.context, val, formatValue(val))
+	}
+	return formatValue(val)
+}
+
+// trustedCode returns val as a string if it is JS in a JS context or CSS in a CSS context.
+func trustedCode(context escapeContext, val any) (string, bool) {
+	switch val := val.(type) {
+	case JS:
+		return string(val), context == contextJS
+	case CSS:
+		return string(val), context == contextCSS
+	}
+	return "", false
+}
+
+// escapeBoundAttr escapes a complete bound attribute value for its context.
+// The renderer HTML-escapes the result.
+func escapeBoundAttr(context escapeContext, val any, rendered string) string {
+	if _, ok := trustedCode(context, val); ok {
+		return rendered
+	}
+	switch context {
+	case contextJS:
+		return escapeJSValue(val)
+	case contextCSS:
+		return filterCSSDeclarations(rendered)
+	case contextURL:
+		if _, ok := val.(URL); ok {
+			return normalizeURL(rendered)
+		}
+		return normalizeURL(filterURL(rendered))
+	case contextSrcset:
+		_, trusted := val.(URL)
+		return filterSrcset(rendered, trusted)
+	case contextSrcdoc:
+		// The browser parses the decoded attribute value as a document, so
+		// the value is escaped once more to keep it text, unless it is trusted HTML.
+		if _, ok := val.(HTML); ok {
+			return rendered
+		}
+		return html.EscapeString(rendered)
+	}
+	return rendered
+}
+
+// escapeJSValue encodes val as a JSON value, which is a safe JS expression.
+// The encoder escapes <, >, & and the U+2028 and U+2029 line terminators,
+// so the value can't close a script element or break a statement.
+func escapeJSValue(val any) string {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return " null "
+	}
+	return string(b)
+}
+
+// escapeJSString escapes s for use inside a quoted JS string or template literal.
+func escapeJSString(s string) string {
+	b, _ := json.Marshal(s)
+	quoted := string(b[1 : len(b)-1])
+	return jsStringReplacer.Replace(quoted)
+}
+
+// jsStringReplacer escapes the quotes JSON leaves alone, and the start of a
+// template literal placeholder.
+var jsStringReplacer = strings.NewReplacer("'", "\\u0027", "`", "\\u0060", "$", "\\u0024")
+
+// escapeCSSString escapes s for use inside a quoted CSS string using hex escapes.
+func escapeCSSString(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for _, c := range s {
+		if c < 0x20 || c == 0x7f || strings.ContainsRune("\"'&()+/:;<>\\{}`", c) {
+			// The trailing space ends the hex escape
+			fmt.Fprintf(&sb, "\\%x ", c)
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// filterCSSValue returns s if it is a plain CSS value like a color, length or
+// keyword, and a harmless replacement otherwise.
+func filterCSSValue(s string) string {
+	for _, c := range s {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.ContainsRune(" #%+-.,!_", c):
+		default:
+			return "ZgotmplZ"
+		}
+	}
+	if hasUnsafeCSS(s) {
+		return "ZgotmplZ"
+	}
+	return s
+}
+
+// filterCSSDeclarations returns a bound style attribute value, or a harmless
+// replacement if it contains constructs that can run script or break out of the attribute.
+func filterCSSDeclarations(s string) string {
+	if strings.ContainsAny(s, "<>\\") || strings.Contains(s, "/*") || hasUnsafeCSS(s) {
+		return "ZgotmplZ"
+	}
+	return s
+}
+
+func hasUnsafeCSS(s string) bool {
+	s = strings.ToLower(s)
+	for _, keyword := range []string{"expression", "javascript:", "vbscript:", "-moz-binding", "behavior", "@import"} {
+		if strings.Contains(s, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterURL replaces URLs with a scheme other than http, https, mailto and tel.
+// Relative URLs are allowed.
+func filterURL(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if i := strings.IndexAny(trimmed, ":/?#"); i >= 0 && trimmed[i] == ':' {
+		switch strings.ToLower(trimmed[:i]) {
+		case "http", "https", "mailto", "tel":
+		default:
+			return unsafeReplacement
+		}
+	}
+	return s
+}
+
+// filterSrcset filters and normalizes each URL of a srcset value. A candidate
+// with an unsafe URL or a descriptor that isn't a plain size like 2x or 640w
+// is replaced. Trusted URLs are normalized without the scheme filter.
+func filterSrcset(s string, trusted bool) string {
+	candidates := strings.Split(s, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		url := fields[0]
+		if !trusted {
+			url = filterURL(url)
+		}
+		fields[0] = normalizeURL(url)
+		for _, descriptor := range fields[1:] {
+			if strings.IndexFunc(descriptor, func(c rune) bool {
+				return c >= utf8.RuneSelf || !isUnreserved(byte(c))
+			}) >= 0 {
+				fields = []string{unsafeReplacement}
+				break
+			}
+		}
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// normalizeURL percent-encodes bytes that are not valid in a URL, keeping
+// reserved characters and existing escapes intact.
+func normalizeURL(s string) string {
+	return percentEncode(s, func(c byte) bool {
+		return isUnreserved(c) || strings.IndexByte(":/?#[]@!$&'()*+,;=%", c) >= 0
+	})
+}
+
+// escapeURLComponent percent-encodes everything but unreserved characters,
+// for values in the query or fragment of a URL.
+func escapeURLComponent(s string) string {
+	return percentEncode(s, isUnreserved)
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func percentEncode(s string, keep func(byte) bool) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; keep(c) {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,131 @@
+package vuego_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestContextualEscaping(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "script value is JSON encoded",
+			template: `<script>var user = {{ name }}, n = {{ n }};</script>`,
+			data:     map[string]any{"name": `</script><b>`, "n": 3},
+			want:     `<script>var user = "\u003c/script\u003e\u003cb\u003e", n = 3;</script>`,
+		},
+		{
+			name:     "script string is escaped without quotes",
+			template: `<script>var a = '{{ name }}'; var b = "x{{ name }}"; var c = ` + "`{{ name }}`" + `;</script>`,
+			data:     map[string]any{"name": `it's "${x}"`},
+			want:     `<script>var a = 'it\u0027s \"\u0024{x}\"'; var b = "xit\u0027s \"\u0024{x}\""; var c = ` + "`it\\u0027s \\\"\\u0024{x}\\\"`" + `;</script>`,
+		},
+		{
+			name: "script comments are not strings",
+			template: `<script>// don't
+var a = {{ n }}; /* it's */ var b = {{ n }};</script>`,
+			data: map[string]any{"n": "x"},
+			want: `<script>// don't
+var a = "x"; /* it's */ var b = "x";</script>`,
+		},
+		{
+			name:     "json filter is written as is",
+			template: `<script>var cfg = {{ cfg | json }};</script>`,
+			data:     map[string]any{"cfg": map[string]any{"a": "</script>"}},
+			want:     `<script>var cfg = {"a":"\u003c/script\u003e"};</script>`,
+		},
+		{
+			name:     "style value is filtered",
+			template: `<style>p { color: {{ good }}; background: {{ bad }}; }</style>`,
+			data:     map[string]any{"good": "#f70", "bad": "url(javascript:alert(1))"},
+			want:     `<style>p { color: #f70; background: ZgotmplZ; }</style>`,
+		},
+		{
+			name:     "style string is hex escaped",
+			template: `<style>p::after { content: "{{ text }}"; }</style>`,
+			data:     map[string]any{"text": `"}</style>`},
+			want:     `<style>p::after { content: "\22 \7d \3c \2f style\3e "; }</style>`,
+		},
+		{
+			name:     "bound url with unsafe scheme",
+			template: `<a :href="url">x</a>`,
+			data:     map[string]any{"url": "  JavaScript:alert(1)"},
+			want:     `<a href="#ZgotmplZ">x</a>`,
+		},
+		{
+			name:     "bound url is normalized",
+			template: `<a :href="url">x</a><img :src="img">`,
+			data:     map[string]any{"url": "https://example.com/a b?q=ü", "img": "/i.png"},
+			want:     `<a href="https://example.com/a%20b?q=%C3%BC">x</a><img src="/i.png">`,
+		},
+		{
+			name:     "bound srcset filters each url",
+			template: `<img :srcset="set"><img srcset="{{ a }} 1x, {{ b }} 2x">`,
+			data:     map[string]any{"set": "/ü.png 1x, javascript:alert(1) 2x, /c.png 640w", "a": "/a.png", "b": "javascript:alert(1)"},
+			want:     `<img srcset="/%C3%BC.png 1x, #ZgotmplZ 2x, /c.png 640w"><img srcset="/a.png 1x, #ZgotmplZ 2x">`,
+		},
+		{
+			name:     "srcset with unsafe descriptor",
+			template: `<img :srcset="set">`,
+			data:     map[string]any{"set": "/a.png 1x\" onerror=alert(1)"},
+			want:     `<img srcset="#ZgotmplZ">`,
+		},
+		{
+			name:     "bound srcdoc is escaped",
+			template: `<iframe :srcdoc="doc"></iframe><iframe srcdoc="<p>{{ doc }}</p>"></iframe>`,
+			data:     map[string]any{"doc": "<script>alert(1)</script>"},
+			want:     `<iframe srcdoc="&amp;lt;script&amp;gt;alert(1)&amp;lt;/script&amp;gt;"></iframe><iframe srcdoc="&lt;p&gt;&amp;lt;script&amp;gt;alert(1)&amp;lt;/script&amp;gt;&lt;/p&gt;"></iframe>`,
+		},
+		{
+			name:     "interpolated url parts",
+			template: `<a href="{{ base }}/u/{{ name }}?q={{ q }}#{{ q }}">x</a><form action="{{ base }}"></form>`,
+			data:     map[string]any{"base": "data:text/html,x", "name": "a b", "q": "a&b=c/d"},
+			want:     `<a href="#ZgotmplZ/u/a%20b?q=a%26b%3Dc%2Fd#a%26b%3Dc%2Fd">x</a><form action="#ZgotmplZ"></form>`,
+		},
+		{
+			name:     "event handler values are neutralized",
+			template: `<button :onclick="code" onmouseover="show('{{ name }}', {{ name }})">x</button>`,
+			data:     map[string]any{"code": "alert(1)", "name": `a'b`},
+			want:     `<button onclick="&#34;alert(1)&#34;" onmouseover="show(&#39;a\u0027b&#39;, &#34;a&#39;b&#34;)">x</button>`,
+		},
+		{
+			name:     "escaped looking values are escaped again",
+			template: `<p :title="title">{{ title }}</p>`,
+			data:     map[string]any{"title": `Tom &amp; Jerry" onclick="x`},
+			want:     `<p title="Tom &amp;amp; Jerry&#34; onclick=&#34;x">Tom &amp;amp; Jerry&#34; onclick=&#34;x</p>`,
+		},
+		{
+			name:     "bound style is filtered",
+			template: `<p style="margin: 0" :style="{ color: color }"></p><p :style="bad"></p>`,
+			data:     map[string]any{"color": "red", "bad": "width: expression(alert(1))"},
+			want:     `<p style="margin:0;color:red;"></p><p style="ZgotmplZ"></p>`,
+		},
+		{
+			name:     "loop values are not interpolated again",
+			template: `<p v-for="i in items" :title="i">{{ i }}</p><p v-if="false"></p><p v-else v-for="i in items" :title="i"></p>`,
+			data:     map[string]any{"items": []string{"{{ secret }}"}, "secret": "s3cr3t"},
+			want:     `<p title="{{ secret }}">{{ secret }}</p><p title="{{ secret }}"></p>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"page.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+			tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
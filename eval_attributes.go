@@ -2,6 +2,8 @@ package vuego
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -18,6 +20,25 @@ func (v *Vue) evalAttributes(ctx VueContext, n *html.Node) (map[string]any, erro
 	results := map[string]any{}
 
 	var newAttrs []html.Attribute
+	// bound holds the newAttrs index of each bound attribute, in source order
+	var bound []int
+
+	bind := func(name string, value any) {
+		// Results keep the evaluated value, also for attributes that don't
+		// render, so components receive props like :open="false" as is
+		results[name] = value
+		rendered, ok := boundAttrValue(name, value)
+		if !ok {
+			return
+		}
+		rendered = escapeBoundAttr(attrContext(name), value, rendered)
+		// Bound attributes keep their source position unless merged with a static one
+		bound = append(bound, len(newAttrs))
+		newAttrs = append(newAttrs, html.Attribute{
+			Key: name,
+			Val: rendered,
+		})
+	}
 
 	// First pass: collect static attributes and evaluate bound ones
 	for _, a := range n.Attr {
@@ -26,11 +47,23 @@ func (v *Vue) evalAttributes(ctx VueContext, n *html.Node) (map[string]any, erro
 
 		// Skip internal attributes that hold already-evaluated content
 		// These should not be re-interpolated
-		if key == "data-v-text-content" || key == "data-v-html-content" {
+		if key == "data-v-text-content" || key == "data-v-html-content" || key == "data-v-html-nodes" {
 			newAttrs = append(newAttrs, html.Attribute{Key: key, Val: val})
 			continue
 		}
 
+		// v-bind="obj" spreads a map into bound attributes
+		if key == "v-bind" {
+			spread, err := v.evalSpread(ctx, val)
+			if err != nil {
+				return nil, &attrError{key: a.Key, name: key, err: err}
+			}
+			for _, attr := range spread {
+				bind(attr.name, attr.value)
+			}
+			continue
+		}
+
 		boundValue := val
 		boundName := key
 		// literal bindings
@@ -48,18 +81,15 @@ func (v *Vue) evalAttributes(ctx VueContext, n *html.Node) (map[string]any, erro
 		case boundName != key:
 			boundValue, err := v.evalBoundAttribute(ctx, boundName, val)
 			if err != nil {
-				return nil, fmt.Errorf("error evaluating attr %s: %w", boundName, err)
-			}
-			if !helpers.IsTruthy(boundValue) {
-				continue
+				return nil, &attrError{key: a.Key, name: boundName, err: err}
 			}
-			results[boundName] = boundValue
+			bind(boundName, boundValue)
 		default:
 			var err error
 			if containsInterpolation(val) {
-				boundValue, err = v.interpolate(ctx, val)
+				boundValue, err = v.interpolateAttr(ctx, boundName, val)
 				if err != nil {
-					return nil, fmt.Errorf("error evaluating attr %s: %w", boundName, err)
+					return nil, &attrError{key: a.Key, name: boundName, err: err}
 				}
 			}
 			newAttrs = append(newAttrs, html.Attribute{
@@ -70,39 +100,56 @@ func (v *Vue) evalAttributes(ctx VueContext, n *html.Node) (map[string]any, erro
 	}
 
 	// Second pass: merge bound attributes with static ones
-	for attrName, boundValue := range results {
-		// Check if there's a static attribute with the same name
-		staticIdx := -1
-		for i, a := range newAttrs {
-			if a.Key == attrName {
-				staticIdx = i
-				break
-			}
+	if len(bound) > 0 {
+		isBound := make(map[int]bool, len(bound))
+		for _, idx := range bound {
+			isBound[idx] = true
 		}
 
-		if staticIdx >= 0 {
-			// Merge with static attribute (special handling for class and style)
-			if attrName == "class" {
-				newAttrs[staticIdx].Val = fmt.Sprintf("%s %s", newAttrs[staticIdx].Val, boundValue)
+		removed := make(map[int]bool)
+		for _, idx := range bound {
+			attrName := newAttrs[idx].Key
+			boundValue := results[attrName]
+
+			// Check if there's a static attribute, or an earlier binding, with the same name
+			staticIdx := -1
+			for i, a := range newAttrs {
+				if i != idx && !removed[i] && a.Key == attrName && (!isBound[i] || i < idx) {
+					staticIdx = i
+					break
+				}
+			}
+			if staticIdx < 0 {
 				continue
 			}
-			if attrName == "style" {
+			removed[idx] = true
+
+			switch {
+			case isBound[staticIdx]:
+				// A later binding of the same attribute wins
+				newAttrs[staticIdx].Val = newAttrs[idx].Val
+			case attrName == "class":
+				// Merge with static attribute (special handling for class and style)
+				newAttrs[staticIdx].Val = fmt.Sprintf("%s %s", newAttrs[staticIdx].Val, boundValue)
+			case attrName == "style":
 				// Merge styles, with bound value taking precedence
 				staticStyle := newAttrs[staticIdx].Val
-				mergedStyle := v.mergeStyles(staticStyle, boundValue.(string))
+				mergedStyle := v.mergeStyles(staticStyle, newAttrs[idx].Val)
 				newAttrs[staticIdx].Val = mergedStyle
-				continue
+			default:
+				// For other attributes, bound value replaces static
+				newAttrs[staticIdx].Val = newAttrs[idx].Val
 			}
-			// For other attributes, bound value replaces static
-			newAttrs[staticIdx].Val = fmt.Sprint(boundValue)
-		} else {
-			if helpers.IsTruthy(boundValue) {
-				newAttrs = append(newAttrs, html.Attribute{
-					Key: attrName,
-					Val: fmt.Sprint(boundValue),
-				})
-				continue
+		}
+
+		if len(removed) > 0 {
+			merged := newAttrs[:0]
+			for i, a := range newAttrs {
+				if !removed[i] {
+					merged = append(merged, a)
+				}
 			}
+			newAttrs = merged
 		}
 	}
 
@@ -125,9 +172,9 @@ func (v *Vue) evalAttributes(ctx VueContext, n *html.Node) (map[string]any, erro
 func (v *Vue) evalBoundAttribute(ctx VueContext, attrName, expr string) (any, error) {
 	expr = strings.TrimSpace(expr)
 
-	// Evaluate interpolation
+	// Evaluate interpolation, the whole value is escaped for its context by the caller
 	if containsInterpolation(expr) {
-		interpolated, err := v.interpolate(ctx, expr)
+		interpolated, err := v.interpolateContext(ctx, expr, contextHTML)
 		if err != nil {
 			return "", err
 		}
@@ -136,12 +183,12 @@ func (v *Vue) evalBoundAttribute(ctx VueContext, attrName, expr string) (any, er
 
 	// Check if the expression is an object literal
 	if strings.HasPrefix(expr, "{") && strings.HasSuffix(expr, "}") {
-		return v.evalObjectBinding(ctx, attrName, expr), nil
+		return v.evalObjectBinding(ctx, attrName, expr)
 	}
 
-	// Check if it's a function call or pipe expression
-	if strings.Contains(expr, "|") || helpers.IsFunctionCall(expr) || helpers.IsComplexExpr(expr) {
-		pipe := parsePipeExpr(expr)
+	// Check if it's a function or method call, or a pipe expression
+	if strings.ContainsAny(expr, "|(") || helpers.IsComplexExpr(expr) {
+		pipe := *v.pipe(expr)
 		val, err := v.evalPipe(ctx, pipe)
 		if err != nil {
 			return "", err
@@ -149,31 +196,63 @@ func (v *Vue) evalBoundAttribute(ctx VueContext, attrName, expr string) (any, er
 		return val, nil
 	}
 
+	// Literals like 0, '' and true, and other expressions that are not variable paths
+	if !helpers.IsPath(expr) {
+		return v.evalExpr(ctx, expr)
+	}
+
 	// Regular variable binding
 	valResolved, ok := ctx.stack.Resolve(expr)
 	if ok {
 		return valResolved, nil
 	}
-	return "", nil
+	return nil, v.checkPath(ctx, expr)
+}
+
+// boundAttrValue returns the rendered value of a bound attribute, and false if
+// the attribute should be removed. Boolean attributes like disabled are kept
+// only for truthy values and render without a value. Other attributes are
+// removed for nil and false, and keep meaningful values like 0 and "".
+// Empty class and style bindings are removed.
+func boundAttrValue(name string, val any) (string, bool) {
+	if helpers.IsBooleanAttr(name) {
+		return "", helpers.IsTruthy(val)
+	}
+	switch val := val.(type) {
+	case nil:
+		return "", false
+	case bool:
+		if !val {
+			return "", false
+		}
+	case string:
+		if val == "" && (name == "class" || name == "style") {
+			return "", false
+		}
+	}
+	return formatValue(val), true
 }
 
 // evalObjectBinding evaluates object literals like {display: "none"} or {active: true, error: false}
 // For :class, treats values as booleans and includes keys where value is truthy.
 // For :style, treats values as strings and builds CSS property:value pairs.
-func (v *Vue) evalObjectBinding(ctx VueContext, attrName, expr string) string {
+func (v *Vue) evalObjectBinding(ctx VueContext, attrName, expr string) (string, error) {
 	expr = strings.TrimSpace(expr)
 	if !strings.HasPrefix(expr, "{") || !strings.HasSuffix(expr, "}") {
-		return ""
+		return "", nil
 	}
 
 	content := expr[1 : len(expr)-1] // Remove { }
-	pairs := v.parseObjectPairs(ctx, content)
+	pairs, err := v.parseObjectPairs(ctx, content)
+	if err != nil {
+		return "", err
+	}
 
 	switch attrName {
 	case "class":
-		return v.buildClassString(pairs)
+		return v.buildClassString(pairs), nil
 	case "style":
-		return v.buildStyleString(pairs)
+		return v.buildStyleString(pairs), nil
 	}
 
 	// For other attributes, just concatenate all values
@@ -183,12 +262,13 @@ func (v *Vue) evalObjectBinding(ctx VueContext, attrName, expr string) string {
 			values = append(values, v)
 		}
 	}
-	return strings.Join(values, " ")
+	return strings.Join(values, " "), nil
 }
 
 // parseObjectPairs parses key:value pairs from an object literal.
-// Returns a slice of resolved values in order.
-func (v *Vue) parseObjectPairs(ctx VueContext, content string) []string {
+// Returns a slice of resolved values in order. Values are evaluated with
+// evalExpr, so strict mode and the expression time limit apply to them.
+func (v *Vue) parseObjectPairs(ctx VueContext, content string) ([]string, error) {
 	var pairs []string
 
 	// Split by comma, but respect quoted strings
@@ -211,8 +291,11 @@ func (v *Vue) parseObjectPairs(ctx VueContext, content string) []string {
 		valueExpr := strings.TrimSpace(item[colonIdx+1:])
 
 		// Try to resolve as expression first (handles literals and expressions)
-		val, err := v.exprEval.Eval(valueExpr, ctx.ExprEnv())
+		val, err := v.evalExpr(ctx, valueExpr)
 		if err != nil {
+			if isFatalError(err) {
+				return nil, err
+			}
 			// Fall back to stack resolution for variable references
 			var ok bool
 			val, ok = ctx.stack.Resolve(valueExpr)
@@ -226,7 +309,7 @@ func (v *Vue) parseObjectPairs(ctx VueContext, content string) []string {
 		pairs = append(pairs, fmt.Sprintf("%s:%v", key, val))
 	}
 
-	return pairs
+	return pairs, nil
 }
 
 // splitObjectItems splits comma-separated items in an object, respecting quoted strings.
@@ -386,14 +469,28 @@ func (v *Vue) mergeStyles(staticStyle, boundStyle string) string {
 		staticMap[k] = v
 	}
 
-	// Rebuild style string
+	// Rebuild style string, keeping static properties first and the declaration order
 	var styles []string
-	for k, v := range staticMap {
-		styles = append(styles, k+":"+v+";")
+	for _, k := range append(styleKeys(staticStyle), styleKeys(boundStyle)...) {
+		if v, ok := staticMap[k]; ok {
+			styles = append(styles, k+":"+v+";")
+			delete(staticMap, k)
+		}
 	}
 	return strings.Join(styles, "")
 }
 
+// styleKeys returns the property names of a CSS style string in declaration order.
+func styleKeys(style string) []string {
+	var keys []string
+	for _, part := range strings.Split(style, ";") {
+		if key, _, ok := strings.Cut(part, ":"); ok {
+			keys = append(keys, strings.TrimSpace(key))
+		}
+	}
+	return keys
+}
+
 // parseStyleMap parses a CSS style string into a map of properties to values.
 func parseStyleMap(style string) map[string]string {
 	result := make(map[string]string)
@@ -420,3 +517,54 @@ func parseStyleMap(style string) map[string]string {
 
 	return result
 }
+
+// spreadAttr is an attribute from a v-bind="obj" spread.
+type spreadAttr struct {
+	name  string
+	value any
+}
+
+// evalSpread evaluates a v-bind="obj" expression into attributes, sorted by name.
+// Nil values spread nothing, other values must be maps with string keys.
+func (v *Vue) evalSpread(ctx VueContext, expr string) ([]spreadAttr, error) {
+	val, err := v.evalDirectiveValue(ctx, expr)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("v-bind expects an object, got %T", val)
+	}
+
+	result := make([]spreadAttr, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		name := iter.Key().String()
+		// Keys come from data, so names that would break out of the tag or
+		// set directives and evaluator state are dropped
+		if !isSpreadAttrName(name) {
+			if v.strict {
+				return nil, newStrictError(ctx, expr, "invalid attribute name %q", name)
+			}
+			continue
+		}
+		result = append(result, spreadAttr{name: name, value: iter.Value().Interface()})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	return result, nil
+}
+
+// isSpreadAttrName reports whether name can be spread as an attribute. It must be
+// a valid HTML attribute name, and not a directive, binding or internal attribute.
+func isSpreadAttrName(name string) bool {
+	if name == "" || strings.ContainsFunc(name, func(c rune) bool {
+		return c <= ' ' || c == 0x7f || strings.ContainsRune("\"'`=<>/", c)
+	}) {
+		return false
+	}
+	lower := strings.ToLower(name)
+	return !strings.HasPrefix(lower, "v-") && !strings.HasPrefix(lower, ":") && !strings.HasPrefix(lower, "@") && !internalAttrs[lower]
+}
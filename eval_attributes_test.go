@@ -53,7 +53,7 @@ func TestVue_EvalAttributes_BoundAndInterpolated(t *testing.T) {
 			name:     "bound attribute with truthy value",
 			template: `<button :disabled="disabled"></button>`,
 			data:     map[string]any{"disabled": true},
-			expected: `<button disabled="true"></button>`,
+			expected: `<button disabled></button>`,
 		},
 		{
 			name:     "normal attribute with interpolation",
@@ -80,10 +80,10 @@ func TestVue_EvalAttributes_BoundAndInterpolated(t *testing.T) {
 			expected: `<input />`,
 		},
 		{
-			name:     "zero is falsey",
+			name:     "zero is kept",
 			template: `<span :data-count="counter"></span>`,
 			data:     map[string]any{"counter": 0},
-			expected: `<span></span>`,
+			expected: `<span data-count="0"></span>`,
 		},
 		{
 			name:     "non-zero is truthy",
@@ -0,0 +1,85 @@
+package vuego
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego/internal/helpers"
+)
+
+// isDynamicComponent reports whether node is a `<component :is="...">` tag.
+func isDynamicComponent(node *html.Node) bool {
+	if node.Data != "component" {
+		return false
+	}
+	return helpers.HasAttr(node, ":is") || helpers.HasAttr(node, "v-bind:is") || helpers.HasAttr(node, "is")
+}
+
+// evalDynamicComponent renders a `<component :is="...">` tag. The evaluated name is
+// resolved through the registered Go components and component shorthands, or used
+// as a template path if it ends with .vuego. The tag is then included like a
+// component tag, with the remaining attributes as props and its children as slots.
+func (v *Vue) evalDynamicComponent(ctx VueContext, node *html.Node, depth int) ([]*html.Node, error) {
+	name, err := v.componentName(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	// The component node shares the children of node for slot extraction
+	component := helpers.CloneNode(node)
+	component.FirstChild, component.LastChild = node.FirstChild, node.LastChild
+	component.Attr = make([]html.Attribute, 0, len(node.Attr))
+	for _, attr := range node.Attr {
+		switch attr.Key {
+		case ":is", "v-bind:is", "is":
+			continue
+		}
+		component.Attr = append(component.Attr, attr)
+	}
+
+	if fn, ok := v.goComponents[name]; ok {
+		component.Data = name
+		return v.evalGoComponent(ctx, component, fn, depth)
+	}
+
+	filename, ok := v.GetComponentFile(name)
+	if !ok {
+		if !strings.HasSuffix(name, ".vuego") {
+			return nil, fmt.Errorf("unknown component '%s'", name)
+		}
+		filename = name
+	}
+
+	component.Data = "template"
+	component.Attr = append(component.Attr, html.Attribute{Key: "include", Val: filename})
+
+	vars, attrs, err := v.componentProps(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+	delete(vars, "include")
+
+	return v.evalInclude(ctx, component, vars, attrs, depth)
+}
+
+// componentName evaluates the is attribute of a dynamic component.
+func (v *Vue) componentName(ctx VueContext, node *html.Node) (string, error) {
+	for _, attr := range node.Attr {
+		switch attr.Key {
+		case "is":
+			return strings.TrimSpace(attr.Val), nil
+		case ":is", "v-bind:is":
+			val, err := v.evalBoundAttribute(ctx, "is", strings.TrimSpace(attr.Val))
+			if err != nil {
+				return "", &attrError{key: attr.Key, name: "is", err: err}
+			}
+			if val == nil || val == "" {
+				return "", fmt.Errorf("component name '%s' evaluated to an empty value", attr.Val)
+			}
+			return fmt.Sprint(val), nil
+		}
+	}
+	return "", nil
+}
@@ -23,11 +23,14 @@ func (v *Vue) evalConditionExpr(ctx VueContext, expr string) (bool, error) {
 	expr = helpers.NormalizeComparisonOperators(expr)
 
 	// Try to evaluate as expr expression first (supports ==, !=, &&, ||, !, <, >, <=, >=, and function calls)
-	result, err := v.exprEval.Eval(expr, ctx.ExprEnv())
+	result, err := v.evalExpr(ctx, expr)
 	if err == nil {
 		// Successfully evaluated with expr - convert to boolean
 		return helpers.IsTruthy(result), nil
 	}
+	if isFatalError(err) {
+		return false, err
+	}
 
 	// If expr evaluation failed and expression starts with !, handle nil negation manually.
 	// expr library fails when trying to negate nil (e.g., "!item.primary" where primary key doesn't exist).
@@ -35,7 +38,10 @@ func (v *Vue) evalConditionExpr(ctx VueContext, expr string) (bool, error) {
 	if strings.HasPrefix(expr, "!") {
 		innerExpr := strings.TrimSpace(expr[1:])
 		// Try to evaluate inner expression (may return nil)
-		innerResult, innerErr := v.exprEval.Eval(innerExpr, ctx.ExprEnv())
+		innerResult, innerErr := v.evalExpr(ctx, innerExpr)
+		if isFatalError(innerErr) {
+			return false, innerErr
+		}
 		if innerErr == nil {
 			// Successfully evaluated - convert nil to bool and negate
 			return !helpers.IsTruthy(innerResult), nil
@@ -45,6 +51,9 @@ func (v *Vue) evalConditionExpr(ctx VueContext, expr string) (bool, error) {
 		if ok {
 			return !helpers.IsTruthy(val), nil
 		}
+		if err := v.checkPath(ctx, innerExpr); err != nil {
+			return false, err
+		}
 		// Undefined value: !undefined = true
 		return true, nil
 	}
@@ -53,7 +62,7 @@ func (v *Vue) evalConditionExpr(ctx VueContext, expr string) (bool, error) {
 	val, ok := ctx.stack.Resolve(expr)
 	if !ok {
 		// Variable not found - return false
-		return false, nil
+		return false, v.checkPath(ctx, expr)
 	}
 
 	return helpers.IsTruthy(val), nil
@@ -117,13 +126,13 @@ func (v *Vue) evalElseIfChain(ctx VueContext, node *html.Node, nodes []*html.Nod
 		if vElseIf := helpers.GetAttr(nextNode, "v-else-if"); vElseIf != "" {
 			ok, err := v.evalConditionExpr(ctx, vElseIf)
 			if err != nil {
-				return nil, 0, err
+				return nil, 0, v.nodeError(ctx, nextNode, err)
 			}
 			if ok {
 				// v-else-if condition is true - evaluate and return this node (don't remove attribute, filter during rendering)
 				// Evaluate the node (evaluateNodeAsElement handles cloning internally)
 				evaluated, err := v.evaluateNodeAsElement(ctx, nextNode, depth)
-				return evaluated, idx, err
+				return evaluated, idx, v.nodeError(ctx, nextNode, err)
 			}
 			// v-else-if condition is false - continue to next
 			continue
@@ -134,7 +143,7 @@ func (v *Vue) evalElseIfChain(ctx VueContext, node *html.Node, nodes []*html.Nod
 			// v-else always matches - evaluate and return this node (don't remove attribute, filter during rendering)
 			// Evaluate the node (evaluateNodeAsElement handles cloning internally)
 			evaluated, err := v.evaluateNodeAsElement(ctx, nextNode, depth)
-			return evaluated, idx, err
+			return evaluated, idx, v.nodeError(ctx, nextNode, err)
 		}
 	}
 
@@ -154,19 +163,24 @@ func (v *Vue) evaluateNodeAsElement(ctx VueContext, node *html.Node, depth int)
 			return nil, err
 		}
 
-		for _, n := range loopNodes {
-			if err := v.evalVHtml(ctx, n); err != nil {
-				return nil, err
-			}
-			if _, err := v.evalAttributes(ctx, n); err != nil {
-				return nil, err
-			}
-		}
-
 		result = append(result, loopNodes...)
 		return result, nil
 	}
 
+	if helpers.HasAttr(node, "v-cache") && ctx.cacheNode != node {
+		return v.evalCached(ctx, node, func(ctx VueContext) ([]*html.Node, error) {
+			return v.evaluateNodeAsElement(ctx, node, depth)
+		})
+	}
+
+	// Directives on templates and components are applied before the element is evaluated
+	if node.Data == "template" || isDynamicComponent(node) || v.goComponents[node.Data] != nil {
+		var err error
+		if node, err = v.applyTagDirectives(ctx, node); err != nil || node == nil {
+			return nil, err
+		}
+	}
+
 	// Special handling for template tags: evaluate bound attributes and set them in current scope
 	if node.Data == "template" {
 		// For templates, bound attributes modify the current scope (don't create new scope)
@@ -185,18 +199,24 @@ func (v *Vue) evaluateNodeAsElement(ctx VueContext, node *html.Node, depth int)
 			// Evaluate the bound attribute expression
 			// Use expression evaluator for templates to support literals and expressions
 			expr := strings.TrimSpace(attr.Val)
-			val, err := v.exprEval.Eval(expr, ctx.ExprEnv())
+			val, err := v.evalExpr(ctx, expr)
 			if err == nil {
 				// Expression evaluated successfully
 				ctx.stack.Set(boundName, val)
 				continue
 			}
+			if isFatalError(err) {
+				return nil, err
+			}
 
 			// Fall back to variable resolution if expression evaluation fails
 			valResolved, ok := ctx.stack.Resolve(expr)
 			if ok {
 				ctx.stack.Set(boundName, valResolved)
 			} else {
+				if err := v.checkPath(ctx, expr); err != nil {
+					return nil, err
+				}
 				// Variable not found - set to nil
 				ctx.stack.Set(boundName, nil)
 			}
@@ -210,8 +230,16 @@ func (v *Vue) evaluateNodeAsElement(ctx VueContext, node *html.Node, depth int)
 		return evaluated, nil
 	}
 
+	if isDynamicComponent(node) {
+		return v.evalDynamicComponent(ctx, node, depth)
+	}
+
+	if fn, ok := v.goComponents[node.Data]; ok {
+		return v.evalGoComponent(ctx, node, fn, depth)
+	}
+
 	// Regular element node processing (no v-for)
-	hasVHtml := helpers.GetAttr(node, "v-html") != ""
+	hasVHtml := hasVHtml(node)
 	var newNode *html.Node
 	if hasVHtml {
 		newNode = helpers.DeepCloneNode(node)
@@ -226,9 +254,14 @@ func (v *Vue) evaluateNodeAsElement(ctx VueContext, node *html.Node, depth int)
 		return nil, err
 	}
 
+	children, keep, err := v.applyElementDirectives(ctx, node, newNode, !hasVHtml)
+	if err != nil || !keep {
+		return nil, err
+	}
+
 	if !hasVHtml {
 		ctx.PushTag(node.Data)
-		newChildren, err := v.evaluateChildren(ctx, node, depth+1)
+		newChildren, err := v.evaluateChildren(ctx, children, depth+1)
 		ctx.PopTag()
 		if err != nil {
 			return nil, err
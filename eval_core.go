@@ -19,27 +19,35 @@ func (v *Vue) evaluateChildren(ctx VueContext, node *html.Node, depth int) ([]*h
 }
 
 func (v *Vue) evaluate(ctx VueContext, nodes []*html.Node, depth int) ([]*html.Node, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	var result []*html.Node
 
 	for i := 0; i < len(nodes); i++ {
 		node := nodes[i]
+		if err := v.countNode(ctx); err != nil {
+			return nil, err
+		}
 
 		switch node.Type {
 		case html.TextNode:
-			interpolated, err := v.interpolate(ctx, node.Data)
+			interpolated, err := v.interpolateText(ctx, node)
 			if err != nil {
-				return nil, fmt.Errorf("in %s: %w", ctx.FormatTemplateChain(), err)
+				if !isFatalError(err) {
+					err = fmt.Errorf("in %s: %w", ctx.FormatTemplateChain(), err)
+				}
+				return nil, v.nodeError(ctx, node, err)
 			}
-			newNode := helpers.CloneNode(node)
-			newNode.Data = interpolated
-			result = append(result, newNode)
+			result = append(result, interpolated...)
 			continue
 
 		case html.ElementNode:
 			tag := node.Data
 
 			// Check for v-once early - skip if already rendered
-			if helpers.HasAttr(node, "v-once") {
+			if helpers.HasAttr(node, "v-once") && ctx.cacheNode != node {
 				vSeenID := helpers.GetAttr(node, "v-once-id")
 				if ctx.seen[vSeenID] {
 					// This v-once element has already been rendered, skip it
@@ -71,7 +79,7 @@ func (v *Vue) evaluate(ctx VueContext, nodes []*html.Node, depth int) ([]*html.N
 			if helpers.HasAttr(node, "v-for") {
 				chainResult, skipCount, err := v.evalVFor(ctx, node, nodes[i:], depth)
 				if err != nil {
-					return nil, err
+					return nil, v.nodeError(ctx, node, err)
 				}
 				result = append(result, chainResult...)
 				i += skipCount
@@ -82,7 +90,7 @@ func (v *Vue) evaluate(ctx VueContext, nodes []*html.Node, depth int) ([]*html.N
 			if tag == "slot" {
 				slotResult, err := v.evalSlot(ctx, node, ctx.SlotScope)
 				if err != nil {
-					return nil, err
+					return nil, v.nodeError(ctx, node, err)
 				}
 				result = append(result, slotResult...)
 				continue
@@ -93,7 +101,7 @@ func (v *Vue) evaluate(ctx VueContext, nodes []*html.Node, depth int) ([]*html.N
 			if helpers.HasAttr(node, "v-if") {
 				chainResult, skipCount, err := v.evalElseIfChain(ctx, node, nodes[i:], depth)
 				if err != nil {
-					return nil, err
+					return nil, v.nodeError(ctx, node, err)
 				}
 				result = append(result, chainResult...)
 				// Skip past the v-else-if and v-else nodes that were part of this chain
@@ -107,11 +115,35 @@ func (v *Vue) evaluate(ctx VueContext, nodes []*html.Node, depth int) ([]*html.N
 				continue
 			}
 
+			if helpers.HasAttr(node, "v-cache") && ctx.cacheNode != node {
+				evaluated, err := v.evalCached(ctx, node, func(ctx VueContext) ([]*html.Node, error) {
+					return v.evaluate(ctx, []*html.Node{node}, depth)
+				})
+				if err != nil {
+					return nil, v.nodeError(ctx, node, err)
+				}
+				result = append(result, evaluated...)
+				continue
+			}
+
+			// Directives on templates and components are applied before the element
+			// is evaluated, so a directive dropping the element skips rendering it
+			src := node
+			if tag == "template" || isDynamicComponent(node) || v.goComponents[tag] != nil {
+				var err error
+				if node, err = v.applyTagDirectives(ctx, node); err != nil {
+					return nil, v.nodeError(ctx, src, err)
+				}
+				if node == nil {
+					continue
+				}
+			}
+
 			// Handle template elements (without v-if/v-for, those are handled above)
 			if tag == "template" {
-				evaluated, err := v.evalTemplate(ctx, []*html.Node{node}, ctx.stack.EnvMap(), depth+1)
+				evaluated, err := v.evalTemplate(ctx, []*html.Node{node}, depth+1)
 				if err != nil {
-					return nil, err
+					return nil, v.nodeError(ctx, src, err)
 				}
 
 				// keep template tag if v-keep is set.
@@ -137,8 +169,26 @@ func (v *Vue) evaluate(ctx VueContext, nodes []*html.Node, depth int) ([]*html.N
 				continue
 			}
 
+			if isDynamicComponent(node) {
+				evaluated, err := v.evalDynamicComponent(ctx, node, depth)
+				if err != nil {
+					return nil, v.nodeError(ctx, src, err)
+				}
+				result = append(result, evaluated...)
+				continue
+			}
+
+			if fn, ok := v.goComponents[tag]; ok {
+				evaluated, err := v.evalGoComponent(ctx, node, fn, depth)
+				if err != nil {
+					return nil, v.nodeError(ctx, src, err)
+				}
+				result = append(result, evaluated...)
+				continue
+			}
+
 			// Check for v-html and v-text early to decide cloning strategy
-			hasVHtml := helpers.GetAttr(node, "v-html") != ""
+			hasVHtml := hasVHtml(node)
 			hasVText := helpers.GetAttr(node, "v-text") != ""
 			var newNode *html.Node
 			if hasVHtml || hasVText {
@@ -150,24 +200,32 @@ func (v *Vue) evaluate(ctx VueContext, nodes []*html.Node, depth int) ([]*html.N
 			}
 
 			if err := v.evalVHtml(ctx, newNode); err != nil {
-				return nil, err
+				return nil, v.nodeError(ctx, node, err)
 			}
 			if err := v.evalVText(ctx, newNode); err != nil {
-				return nil, err
+				return nil, v.nodeError(ctx, node, err)
 			}
 			if err := v.evalVShow(ctx, newNode); err != nil {
-				return nil, err
+				return nil, v.nodeError(ctx, node, err)
 			}
 			if _, err := v.evalAttributes(ctx, newNode); err != nil {
-				return nil, err
+				return nil, v.nodeError(ctx, node, err)
+			}
+
+			children, keep, err := v.applyElementDirectives(ctx, node, newNode, !hasVHtml && !hasVText)
+			if err != nil {
+				return nil, v.nodeError(ctx, node, err)
+			}
+			if !keep {
+				continue
 			}
 
 			if !hasVHtml && !hasVText {
 				ctx.PushTag(node.Data)
-				newChildren, err := v.evaluateChildren(ctx, node, depth+1)
+				newChildren, err := v.evaluateChildren(ctx, children, depth+1)
 				ctx.PopTag()
 				if err != nil {
-					return nil, err
+					return nil, v.nodeError(ctx, node, err)
 				}
 
 				newNode.FirstChild = nil
@@ -2,11 +2,14 @@ package vuego
 
 import (
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 
 	"golang.org/x/net/html"
 
 	"github.com/titpetric/vuego/internal/helpers"
+	ireflect "github.com/titpetric/vuego/internal/reflect"
 )
 
 // parseLoops parses v-for strings like:
@@ -58,13 +61,7 @@ func (v *Vue) propagateTemplateAttributes(ctx VueContext, node *html.Node) {
 			}
 			// Get the value that was set in the current scope and propagate to parent
 			if val, ok := ctx.stack.Lookup(boundName); ok {
-				// Pop current scope, set in parent, push back
-				topIdx := len(ctx.stack.stack) - 1
-				topMap := ctx.stack.stack[topIdx]
-				ctx.stack.stack = ctx.stack.stack[:topIdx]
-				ctx.stack.Set(boundName, val)
-				ctx.stack.stack = append(ctx.stack.stack, topMap)
-				ctx.stack.envCache = nil
+				ctx.stack.setParent(boundName, val)
 			}
 		}
 	}
@@ -84,15 +81,6 @@ func (v *Vue) evalVFor(ctx VueContext, node *html.Node, nodes []*html.Node, dept
 			return result, skipCount, err
 		}
 
-		for _, n := range loopNodes {
-			if err := v.evalVHtml(ctx, n); err != nil {
-				return result, skipCount, err
-			}
-			if _, err := v.evalAttributes(ctx, n); err != nil {
-				return result, skipCount, err
-			}
-		}
-
 		result = append(result, loopNodes...)
 
 		// If v-for produced no results, check for v-else on the next sibling
@@ -108,7 +96,7 @@ func (v *Vue) evalVFor(ctx VueContext, node *html.Node, nodes []*html.Node, dept
 					// Evaluate the v-else node without cloning yet - let evaluateNodeAsElement handle it
 					vElseResult, err := v.evaluateNodeAsElement(ctx, nextNode, depth)
 					if err != nil {
-						return result, skipCount, err
+						return result, skipCount, v.nodeError(ctx, nextNode, err)
 					}
 					result = append(result, vElseResult...)
 					skipCount = j
@@ -122,29 +110,41 @@ func (v *Vue) evalVFor(ctx VueContext, node *html.Node, nodes []*html.Node, dept
 }
 
 func (v *Vue) evalFor(ctx VueContext, node *html.Node, expr string, depth int) ([]*html.Node, error) {
-	vars, collectionName, err := parseFor(expr)
+	loop := v.loop(expr)
+	if loop.err != nil {
+		return nil, loop.err
+	}
+	vars := loop.vars
+	if len(vars) > 3 {
+		return nil, fmt.Errorf("v-for variables must be 1 to 3, got %d", len(vars))
+	}
+
+	// The collection is a variable or an expression, like `10` or `range(1, 5)`
+	if err := v.checkPath(ctx, loop.collection); err != nil {
+		return nil, err
+	}
+	collection, err := v.evalDirectiveValue(ctx, loop.collection)
 	if err != nil {
 		return nil, err
 	}
+	if err := v.checkCollection(ctx, loop.collection, collection); err != nil {
+		return nil, err
+	}
 
 	var result []*html.Node
 
-	err = ctx.stack.ForEach(collectionName, func(index int, value any) error {
-		iterNode := helpers.DeepCloneNode(node)
-		helpers.RemoveAttr(iterNode, "v-for")
+	// $loop of an enclosing v-for, if any
+	parent, _ := ctx.stack.Lookup("$loop")
+	length, known := collectionLen(collection)
 
-		ctx.stack.Push(nil)
+	// Evaluation only reads the loop body and produces new output nodes,
+	// so every iteration evaluates the same body.
+	iterNode := v.loopBody(ctx, node)
 
-		switch len(vars) {
-		case 1:
-			ctx.stack.Set(vars[0], value)
-		case 2:
-			ctx.stack.Set(vars[0], index)
-			ctx.stack.Set(vars[1], value)
-		default:
-			ctx.stack.Pop()
-			return fmt.Errorf("v-for variables must be 1 or 2, got %d", len(vars))
-		}
+	each := func(index int, last bool, key, value any) error {
+		ctx.stack.Push(nil)
+		ctx.stack.Set("$loop", loopMeta(index, length, known, last, parent))
+		setLoopVars(ctx, vars, index, key, value)
 
 		evaluated, err := v.evaluate(ctx, []*html.Node{iterNode}, depth)
 		if err != nil {
@@ -159,10 +159,202 @@ func (v *Vue) evalFor(ctx VueContext, node *html.Node, expr string, depth int) (
 		ctx.stack.Pop()
 		result = append(result, evaluated...)
 		return nil
+	}
+
+	if helpers.HasAttr(node, "v-sort-by") || helpers.HasAttr(node, "v-order") {
+		items, err := v.sortedItems(ctx, node, vars, collection)
+		if err != nil {
+			return nil, err
+		}
+		length, known = len(items), true
+		for i, item := range items {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := each(i, i == len(items)-1, item.key, item.value); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+
+	// The length of channels and iterators is not known up front, so each
+	// item is evaluated once the next one is received, to know the last item.
+	index := 0
+	var pending *loopItem
+	err = iterate(ctx.Context(), collection, func(key, value any) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := v.countIteration(ctx); err != nil {
+			return err
+		}
+		if known {
+			err := each(index, index == length-1, key, value)
+			index++
+			return err
+		}
+		if pending != nil {
+			if err := each(index, false, pending.key, pending.value); err != nil {
+				return err
+			}
+			index++
+		}
+		pending = &loopItem{key: key, value: value}
+		return nil
 	})
 	if err != nil {
+		// Receiving from a channel stops with the plain context error
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, err
 	}
+	if pending != nil {
+		if err := each(index, true, pending.key, pending.value); err != nil {
+			return nil, err
+		}
+	}
 
 	return result, nil
 }
+
+// loopMeta returns the `$loop` variable of a v-for iteration. The length is
+// nil for channels and iterators, as it is not known up front. Even and odd
+// follow the index, so the first item is even.
+func loopMeta(index, length int, known, last bool, parent any) map[string]any {
+	meta := map[string]any{
+		"index":    index,
+		"position": index + 1,
+		"first":    index == 0,
+		"last":     last,
+		"length":   nil,
+		"even":     index%2 == 0,
+		"odd":      index%2 == 1,
+		"depth":    1,
+		"parent":   parent,
+	}
+	if known {
+		meta["length"] = length
+	}
+	if p, ok := parent.(map[string]any); ok {
+		if depth, ok := p["depth"].(int); ok {
+			meta["depth"] = depth + 1
+		}
+	}
+	return meta
+}
+
+// collectionLen returns the number of items of a collection, if it is known
+// before iterating it.
+func collectionLen(collection any) (int, bool) {
+	rv := reflect.ValueOf(collection)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return max(int(rv.Int()), 0), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(rv.Uint()), true
+	case reflect.Invalid:
+		return 0, true
+	}
+	return 0, false
+}
+
+// setLoopVars binds the v-for variables of an item: `item`, `(key, item)`
+// or `(item, key, index)`, as in Vue. The key is the index of the item, or
+// its key for maps and iter.Seq2.
+func setLoopVars(ctx VueContext, vars []string, index int, key, value any) {
+	switch len(vars) {
+	case 1:
+		ctx.stack.Set(vars[0], value)
+	case 2:
+		ctx.stack.Set(vars[0], key)
+		ctx.stack.Set(vars[1], value)
+	case 3:
+		ctx.stack.Set(vars[0], value)
+		ctx.stack.Set(vars[1], key)
+		ctx.stack.Set(vars[2], index)
+	}
+}
+
+// loopItem is an item of a sorted v-for collection.
+type loopItem struct {
+	key   any
+	value any
+	sort  any
+}
+
+// sortedItems collects the items of a v-for with a `v-sort-by` or `v-order`
+// attribute and sorts them. The v-sort-by expression is evaluated for each
+// item with the loop variables and the fields of the item in scope, so
+// `v-sort-by="price"` and `v-sort-by="item.price * item.qty"` both work.
+// Without v-sort-by, maps are sorted by key and other collections by value.
+// The order is `asc` by default, or `desc`.
+func (v *Vue) sortedItems(ctx VueContext, node *html.Node, vars []string, collection any) ([]loopItem, error) {
+	sortBy := strings.TrimSpace(helpers.GetAttr(node, "v-sort-by"))
+	order := strings.ToLower(strings.TrimSpace(helpers.GetAttr(node, "v-order")))
+	if order != "" && order != "asc" && order != "desc" {
+		return nil, &attrError{key: "v-order", name: "v-order", err: fmt.Errorf("expected asc or desc, got '%s'", order)}
+	}
+	byKey := sortBy == "" && reflect.ValueOf(collection).Kind() == reflect.Map
+
+	var items []loopItem
+	err := iterate(ctx.Context(), collection, func(key, value any) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := v.countIteration(ctx); err != nil {
+			return err
+		}
+
+		item := loopItem{key: key, value: value, sort: value}
+		switch {
+		case byKey:
+			item.sort = key
+		case sortBy != "":
+			ctx.stack.Push(nil)
+			for k, val := range itemFields(value) {
+				ctx.stack.Set(k, val)
+			}
+			setLoopVars(ctx, vars, len(items), key, value)
+			val, err := v.evalDirectiveValue(ctx, sortBy)
+			ctx.stack.Pop()
+			if err != nil {
+				return &attrError{key: "v-sort-by", name: "v-sort-by", err: err}
+			}
+			item.sort = val
+		}
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+
+	slices.SortStableFunc(items, func(a, b loopItem) int {
+		if order == "desc" {
+			return compareValues(b.sort, a.sort)
+		}
+		return compareValues(a.sort, b.sort)
+	})
+	return items, nil
+}
+
+// itemFields returns the fields of a map or struct item, for v-sort-by.
+func itemFields(value any) map[string]any {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String {
+		fields := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			fields[iter.Key().String()] = iter.Value().Interface()
+		}
+		return fields
+	}
+	return ireflect.StructToMap(value)
+}
@@ -2,8 +2,11 @@ package vuego_test
 
 import (
 	"bytes"
+	"context"
+	"iter"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/titpetric/vuego"
 	"github.com/titpetric/vuego/testing/assert"
@@ -84,6 +87,143 @@ func TestVue_EvalFor(t *testing.T) {
 			},
 			expected: "<span><button>Page 1</button></span><span></span><span><button>Page 3</button></span>",
 		},
+		{
+			name:     "v-for over an integer",
+			template: "<span v-for=\"(i, n) in 3\">{{ i }}:{{ n }}</span>",
+			expected: "<span>0:1</span><span>1:2</span><span>2:3</span>",
+		},
+		{
+			name:     "v-for over an integer variable",
+			template: "<span v-for=\"n in count\">{{ n }}</span>",
+			data:     map[string]any{"count": 2},
+			expected: "<span>1</span><span>2</span>",
+		},
+		{
+			name:     "v-for over range",
+			template: "<span v-for=\"i in range(1, 5)\">{{ i }}</span><b v-for=\"i in range(6, 0, -2)\">{{ i }}</b>",
+			expected: "<span>1</span><span>2</span><span>3</span><span>4</span><b>6</b><b>4</b><b>2</b>",
+		},
+		{
+			name:     "v-for over range knows its length",
+			template: "<span v-for=\"i in range(3)\">{{ i }}/{{ $loop.length }}<b v-if=\"$loop.last\">!</b></span>",
+			expected: "<span>0/3</span><span>1/3</span><span>2/3<b>!</b></span>",
+		},
+		{
+			name:     "v-for over iter.Seq",
+			template: "<span v-for=\"(i, user) in users\">{{ i }}:{{ user.name }}</span>",
+			data: map[string]any{"users": func(yield func(map[string]any) bool) {
+				_ = yield(map[string]any{"name": "Ana"}) && yield(map[string]any{"name": "Bob"})
+			}},
+			expected: "<span>0:Ana</span><span>1:Bob</span>",
+		},
+		{
+			name:     "v-for over iter.Seq2 binds key and value",
+			template: "<span v-for=\"(id, name) in users\">{{ id }}:{{ name }}</span>",
+			data: map[string]any{"users": iter.Seq2[int, string](func(yield func(int, string) bool) {
+				_ = yield(7, "Ana") && yield(9, "Bob")
+			})},
+			expected: "<span>7:Ana</span><span>9:Bob</span>",
+		},
+		{
+			name:     "v-for over a channel",
+			template: "<span v-for=\"(i, row) in rows\">{{ i }}:{{ row }}</span>",
+			data: map[string]any{"rows": func() <-chan string {
+				ch := make(chan string, 2)
+				ch <- "a"
+				ch <- "b"
+				close(ch)
+				return ch
+			}()},
+			expected: "<span>0:a</span><span>1:b</span>",
+		},
+		{
+			name:     "v-for over a map binds the key in key order",
+			template: "<span v-for=\"(k, v) in obj\">{{ k }}={{ v }}</span>",
+			data:     map[string]any{"obj": map[string]any{"c": 3, "a": 1, "b": 2}},
+			expected: "<span>a=1</span><span>b=2</span><span>c=3</span>",
+		},
+		{
+			name:     "v-for over a map with int keys",
+			template: "<span v-for=\"(k, v) in obj\">{{ k }}={{ v }}</span>",
+			data:     map[string]any{"obj": map[int]string{10: "x", 2: "y", 1: "z"}},
+			expected: "<span>1=z</span><span>2=y</span><span>10=x</span>",
+		},
+		{
+			name:     "v-for with value, key and index",
+			template: "<span v-for=\"(value, key, index) in obj\">{{ index }}.{{ key }}={{ value }}</span>",
+			data:     map[string]any{"obj": map[string]any{"b": "B", "a": "A"}},
+			expected: "<span>0.a=A</span><span>1.b=B</span>",
+		},
+		{
+			name:     "v-for sorted by field",
+			template: "<span v-for=\"item in items\" v-sort-by=\"price\" v-order=\"desc\">{{ item.name }}</span>",
+			data: map[string]any{"items": []map[string]any{
+				{"name": "tea", "price": 3},
+				{"name": "cake", "price": 4.5},
+				{"name": "water", "price": 1},
+			}},
+			expected: "<span>cake</span><span>tea</span><span>water</span>",
+		},
+		{
+			name:     "v-for sorted by expression",
+			template: "<span v-for=\"(i, item) in items\" v-sort-by=\"item.price * item.qty\">{{ i }}:{{ item.name }}</span>",
+			data: map[string]any{"items": []map[string]any{
+				{"name": "tea", "price": 3, "qty": 2},
+				{"name": "cake", "price": 4, "qty": 1},
+				{"name": "water", "price": 1, "qty": 10},
+			}},
+			expected: "<span>1:cake</span><span>0:tea</span><span>2:water</span>",
+		},
+		{
+			name:     "v-for sorted by value",
+			template: "<span v-for=\"n in nums\" v-order=\"desc\">{{ n }}</span>",
+			data:     map[string]any{"nums": []int{2, 10, 1}},
+			expected: "<span>10</span><span>2</span><span>1</span>",
+		},
+		{
+			name:     "v-for over a map in descending key order",
+			template: "<span v-for=\"(k, v) in obj\" v-order=\"desc\">{{ k }}</span>",
+			data:     map[string]any{"obj": map[string]int{"a": 1, "c": 3, "b": 2}},
+			expected: "<span>c</span><span>b</span><span>a</span>",
+		},
+		{
+			name:     "v-for keeps a plain order attribute",
+			template: "<ol v-for=\"n in nums\" order=\"desc\"><li>{{ n }}</li></ol>",
+			data:     map[string]any{"nums": []int{2, 1}},
+			expected: "<ol order=\"desc\"><li>2</li></ol><ol order=\"desc\"><li>1</li></ol>",
+		},
+		{
+			name:     "v-for with $loop",
+			template: "<span v-for=\"x in items\">{{ $loop.index }}/{{ $loop.position }}/{{ $loop.length }}/{{ $loop.first }}/{{ $loop.last }}/{{ $loop.even }}/{{ $loop.odd }}</span>",
+			data:     map[string]any{"items": []string{"a", "b", "c"}},
+			expected: "<span>0/1/3/true/false/true/false</span><span>1/2/3/false/false/false/true</span><span>2/3/3/false/true/true/false</span>",
+		},
+		{
+			name:     "v-for with $loop separators",
+			template: "<p><span v-for=\"x in items\">{{ x }}{{ $loop.last ? \"\" : \",\" }}</span><b v-for=\"x in items\"><i v-if=\"$loop.last\">{{ $loop.position | string }}</i></b></p>",
+			data:     map[string]any{"items": []string{"a", "b", "c"}},
+			expected: "<p><span>a,</span><span>b,</span><span>c</span><b></b><b></b><b><i>3</i></b></p>",
+		},
+		{
+			name:     "v-for with nested $loop",
+			template: "<p v-for=\"row in rows\"><i v-for=\"col in row\">{{ $loop.parent.position }}.{{ $loop.position }}@{{ $loop.depth }}</i></p>",
+			data:     map[string]any{"rows": [][]int{{1, 2}, {3}}},
+			expected: "<p><i>1.1@2</i><i>1.2@2</i></p><p><i>2.1@2</i></p>",
+		},
+		{
+			name:     "v-for with $loop over an iterator",
+			template: "<span v-for=\"x in seq\">{{ x }}:{{ $loop.last }}:{{ $loop.length }}</span>",
+			data: map[string]any{"seq": iter.Seq[string](func(yield func(string) bool) {
+				_ = yield("a") && yield("b")
+			})},
+			expected: "<span>a:false:</span><span>b:true:</span>",
+		},
+		{
+			name:     "v-for with $loop when sorted",
+			template: "<span v-for=\"n in nums\" v-order=\"desc\">{{ n }}{{ $loop.last ? \"\" : \",\" }}</span>",
+			data:     map[string]any{"nums": []int{1, 3, 2}},
+			expected: "<span>3,</span><span>2,</span><span>1</span>",
+		},
 	}
 
 	for _, tc := range tests {
@@ -102,6 +242,32 @@ func TestVue_EvalFor(t *testing.T) {
 	}
 }
 
+func TestVue_EvalForInvalidOrder(t *testing.T) {
+	fs := fstest.MapFS{
+		"test.vuego": &fstest.MapFile{Data: []byte(`<span v-for="n in nums" v-order="random">{{ n }}</span>`)},
+	}
+	vue := vuego.NewVue(fs)
+
+	err := vue.RenderFragment(t.Context(), &bytes.Buffer{}, "test.vuego", map[string]any{"nums": []int{1}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expected asc or desc, got 'random'")
+}
+
+func TestVue_EvalForChannelCancelled(t *testing.T) {
+	fs := fstest.MapFS{
+		"test.vuego": &fstest.MapFile{Data: []byte(`<span v-for="row in rows">{{ row }}</span>`)},
+	}
+	vue := vuego.NewVue(fs)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	// The channel is never closed, the render stops with the context
+	rows := make(chan int)
+	err := vue.RenderFragment(ctx, &bytes.Buffer{}, "test.vuego", map[string]any{"rows": rows})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestParseFor(t *testing.T) {
 	// Note: parseFor is not exported, so we test it indirectly through eval
 	// This test ensures v-for parsing works correctly
@@ -2,16 +2,56 @@ package vuego
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"golang.org/x/net/html"
 
 	"github.com/titpetric/vuego/internal/helpers"
-	"github.com/titpetric/vuego/internal/parser"
 )
 
 // evalInclude processes a <template include="..."> tag with the given vars map.
+// The evaluated attributes of the tag are used for attribute fallthrough.
 // Handles stack push/pop properly using defer to ensure cleanup even on error.
-func (v *Vue) evalInclude(ctx VueContext, node *html.Node, vars map[string]any, depth int) ([]*html.Node, error) {
+func (v *Vue) evalInclude(ctx VueContext, node *html.Node, vars map[string]any, attrs []html.Attribute, depth int) ([]*html.Node, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	name := helpers.GetAttr(node, "include")
+	if err := v.checkInclude(ctx, name); err != nil {
+		return nil, err
+	}
+
+	entry, err := v.loadInclude(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s (included from %s): %w", name, ctx.FormatTemplateChain(), err)
+	}
+	frontMatter, compDom, specs, declared := entry.frontMatter, entry.dom, entry.props, entry.declared
+
+	err = entry.propsErr
+	if err == nil {
+		err = applyProps(specs, vars)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in %s (included from %s): %w", name, ctx.FormatTemplateChain(), err)
+	}
+
+	if v.strict && specs != nil {
+		for _, attr := range attrs {
+			if !declared[attr.Key] && !isFallthroughAttr(attr.Key) && attr.Key != "id" && isPropAttr(attr.Key) {
+				return nil, newStrictError(ctx, attr.Key, "undeclared prop '%s' for %s", attr.Key, name)
+			}
+		}
+	}
+
+	fallthroughAttrs := componentAttrs(declared, attrs)
+	attrsVar := make(map[string]any, len(fallthroughAttrs))
+	for _, attr := range fallthroughAttrs {
+		attrsVar[attr.Key] = vars[attr.Key]
+	}
+	vars["$attrs"] = attrsVar
+
 	ctx.stack.Push(vars)
 	defer ctx.stack.Pop()
 
@@ -21,7 +61,7 @@ func (v *Vue) evalInclude(ctx VueContext, node *html.Node, vars map[string]any,
 	}
 
 	// Merge inherited slots from parent template (passed via __slotScope__ in data)
-	if inheritedSlotScopeData, ok := ctx.stack.EnvMap()["__slotScope__"]; ok {
+	if inheritedSlotScopeData, ok := ctx.stack.Lookup("__slotScope__"); ok {
 		if inheritedSlotScope, ok := inheritedSlotScopeData.(*SlotScope); ok {
 			for slotName, slotContent := range inheritedSlotScope.Slots {
 				if ctx.SlotScope.GetSlot(slotName) == nil {
@@ -33,29 +73,141 @@ func (v *Vue) evalInclude(ctx VueContext, node *html.Node, vars map[string]any,
 		}
 	}
 
-	name := helpers.GetAttr(node, "include")
-	frontMatter, templateBytes, err := v.loader.loadFragment(name)
-	if err != nil {
-		return nil, fmt.Errorf("error loading %s (included from %s): %w", name, ctx.FormatTemplateChain(), err)
-	}
-
 	// Merge front-matter data (authoritative - overrides passed data)
 	for k, v := range frontMatter {
+		if k == "props" && specs != nil {
+			continue
+		}
 		ctx.stack.Set(k, v)
 	}
 
-	// Parse template bytes into DOM
-	compDom, err := parser.ParseTemplateBytes(templateBytes)
+	// Validate and process template tag
+	processedDom, err := v.evalTemplate(ctx.withCompiled(entry.compiled), compDom, depth+1)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing %s (included from %s): %w", name, ctx.FormatTemplateChain(), err)
+		return nil, fmt.Errorf("error in %s (included from %s): %w", name, ctx.FormatTemplateChain(), err)
 	}
 
-	// Validate and process template tag
-	processedDom, err := v.evalTemplate(ctx, compDom, ctx.stack.EnvMap(), depth+1)
+	childCtx := ctx.WithTemplate(name).withCompiled(entry.compiled)
+	result, err := v.evaluate(childCtx, processedDom, depth+1)
 	if err != nil {
-		return nil, fmt.Errorf("error in %s (included from %s): %w", name, ctx.FormatTemplateChain(), err)
+		return nil, err
+	}
+
+	if inheritAttrs(compDom) {
+		if root := rootElement(result); root != nil {
+			v.mergeAttrs(root, fallthroughAttrs)
+		}
+	}
+	return result, nil
+}
+
+// componentAttrs returns the attributes of a component tag that fall through to
+// the root element of the component, and are available as $attrs.
+//
+// If the component declares props, all other attributes fall through.
+// Otherwise every attribute is a prop, and only class, style, data-* and
+// aria-* attributes fall through.
+func componentAttrs(declared map[string]bool, attrs []html.Attribute) []html.Attribute {
+	var result []html.Attribute
+	for _, attr := range attrs {
+		if !isPropAttr(attr.Key) || internalAttrs[strings.ToLower(attr.Key)] {
+			continue
+		}
+		if len(declared) > 0 {
+			if declared[attr.Key] {
+				continue
+			}
+		} else if !isFallthroughAttr(attr.Key) {
+			continue
+		}
+		result = append(result, attr)
+	}
+	return result
+}
+
+// isPropAttr reports whether an attribute of a component tag is passed to the
+// component, rather than being the include itself or a directive.
+func isPropAttr(name string) bool {
+	return name != "include" && !strings.HasPrefix(name, "v-") && !shouldIgnoreAttr(name)
+}
+
+// isFallthroughAttr reports whether an attribute of a component without
+// declared props falls through to its root element.
+func isFallthroughAttr(name string) bool {
+	if internalAttrs[strings.ToLower(name)] {
+		return false
+	}
+	return name == "class" || name == "style" || strings.HasPrefix(name, "data-") || strings.HasPrefix(name, "aria-")
+}
+
+// declaredProps returns the props declared with :required on the root template of a component.
+func declaredProps(compDom []*html.Node) map[string]bool {
+	declared := make(map[string]bool)
+	if len(compDom) == 0 || compDom[0].Type != html.ElementNode || compDom[0].Data != "template" {
+		return declared
+	}
+
+	for _, attr := range compDom[0].Attr {
+		if attr.Key != ":require" && attr.Key != ":required" {
+			continue
+		}
+		for _, field := range strings.Split(attr.Val, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				declared[field] = true
+			}
+		}
+	}
+	return declared
+}
+
+// inheritAttrs reports whether attributes fall through to the root element of a
+// component. Components opt out with `<template inherit-attrs="false">`.
+func inheritAttrs(compDom []*html.Node) bool {
+	if len(compDom) == 0 || compDom[0].Type != html.ElementNode || compDom[0].Data != "template" {
+		return true
+	}
+	return helpers.GetAttr(compDom[0], "inherit-attrs") != "false"
+}
+
+// rootElement returns the single root element of the rendered nodes,
+// or nil if there is no single root element.
+func rootElement(nodes []*html.Node) *html.Node {
+	var root *html.Node
+	for _, node := range nodes {
+		switch node.Type {
+		case html.ElementNode:
+			if root != nil {
+				return nil
+			}
+			root = node
+		case html.TextNode:
+			if strings.TrimSpace(node.Data) != "" {
+				return nil
+			}
+		case html.CommentNode:
+		default:
+			return nil
+		}
 	}
+	return root
+}
 
-	childCtx := ctx.WithTemplate(name)
-	return v.evaluate(childCtx, processedDom, depth+1)
+// mergeAttrs applies fallthrough attributes to an element. Classes are appended,
+// styles are merged and other attributes replace those of the element.
+func (v *Vue) mergeAttrs(node *html.Node, attrs []html.Attribute) {
+	for _, attr := range attrs {
+		idx := slices.IndexFunc(node.Attr, func(a html.Attribute) bool {
+			return a.Key == attr.Key
+		})
+		switch {
+		case idx < 0:
+			node.Attr = append(node.Attr, attr)
+		case attr.Key == "class":
+			node.Attr[idx].Val = strings.TrimSpace(node.Attr[idx].Val + " " + attr.Val)
+		case attr.Key == "style":
+			node.Attr[idx].Val = v.mergeStyles(node.Attr[idx].Val, attr.Val)
+		default:
+			node.Attr[idx].Val = attr.Val
+		}
+	}
 }
@@ -62,7 +62,10 @@ func (v *Vue) evalSlot(ctx VueContext, node *html.Node, slotScope *SlotScope) ([
 		propName := attr.Key[1:]
 
 		// Evaluate the binding value
-		val, err := v.exprEval.Eval(attr.Val, ctx.ExprEnv())
+		val, err := v.evalExpr(ctx, attr.Val)
+		if isFatalError(err) {
+			return nil, err
+		}
 		if err == nil && val != nil {
 			slotProps[propName] = val
 		}
@@ -108,8 +111,10 @@ func (v *Vue) evalSlot(ctx VueContext, node *html.Node, slotScope *SlotScope) ([
 				}
 				result = append(result, children...)
 			} else {
-				// Use the provided content as-is
-				result = append(result, slotContent.Nodes...)
+				// Use the provided content as-is, cloned since it may belong to a cached template
+				for _, n := range slotContent.Nodes {
+					result = append(result, helpers.DeepCloneNode(n))
+				}
 			}
 
 			return result, nil
@@ -117,11 +122,25 @@ func (v *Vue) evalSlot(ctx VueContext, node *html.Node, slotScope *SlotScope) ([
 	}
 
 	// Check for inherited slots from layout (passed via __slotScope__ in context data)
-	if inheritedSlotScopeData, ok := ctx.stack.EnvMap()["__slotScope__"]; ok {
+	if inheritedSlotScopeData, ok := ctx.stack.Lookup("__slotScope__"); ok {
 		if inheritedSlotScope, ok := inheritedSlotScopeData.(*SlotScope); ok {
 			if slotContent := inheritedSlotScope.GetSlot(slotName); slotContent != nil {
-				// Use the inherited slot content directly (already parsed as DOM nodes)
-				return slotContent.Nodes, nil
+				// Use the inherited slot content (already parsed as DOM nodes), cloned
+				// since it belongs to the cached page template
+				result := make([]*html.Node, 0, len(slotContent.Nodes))
+				for _, n := range slotContent.Nodes {
+					result = append(result, helpers.DeepCloneNode(n))
+				}
+				return result, nil
+			}
+		}
+	}
+
+	// In a layout, the default slot receives the content of the template being wrapped
+	if slotName == "default" && ctx.SlotScope == nil {
+		if content, ok := ctx.stack.Lookup("content"); ok {
+			if content, ok := content.(*layoutContent); ok {
+				return content.clone(), nil
 			}
 		}
 	}
@@ -16,7 +16,7 @@ import (
 // A template element may repeat `:required` as needed. If a value is not provided,
 // template evaluation fails with an error that needs to be bubbled up preventing render.
 // If a `<template>` element has an include attribute, it loads and processes the included file.
-func (v *Vue) evalTemplate(ctx VueContext, nodes []*html.Node, componentData map[string]any, depth int) ([]*html.Node, error) {
+func (v *Vue) evalTemplate(ctx VueContext, nodes []*html.Node, depth int) ([]*html.Node, error) {
 	// If no nodes, return empty
 	if len(nodes) == 0 {
 		return nodes, nil
@@ -28,26 +28,14 @@ func (v *Vue) evalTemplate(ctx VueContext, nodes []*html.Node, componentData map
 
 		// Check for include attribute - handle inclusion first
 		if helpers.HasAttr(node, "include") {
-			vars, err := v.evalAttributes(ctx, node)
+			vars, attrs, err := v.componentProps(ctx, node)
 			if err != nil {
 				return nil, err
 			}
 
 			delete(vars, "include")
 
-			// auto decode params as json, e.g. `data="{...}"` or `[...]`
-			for k, v := range vars {
-				if vs, ok := v.(string); ok {
-					if strings.HasPrefix(vs, "{") || strings.HasPrefix(vs, "[") {
-						var out any
-						if err := json.Unmarshal([]byte(vs), &out); err == nil {
-							vars[k] = out
-						}
-					}
-				}
-			}
-
-			evaluated, err := v.evalInclude(ctx, node, vars, depth)
+			evaluated, err := v.evalInclude(ctx, node, vars, attrs, depth)
 			if err != nil {
 				return nil, err
 			}
@@ -69,30 +57,24 @@ func (v *Vue) evalTemplate(ctx VueContext, nodes []*html.Node, componentData map
 			}
 		}
 
-		// Check if all required attributes are provided
+		// Check if all required attributes are provided in scope
 		for _, required := range requiredAttrs {
-			if _, exists := componentData[required]; !exists {
+			if _, exists := ctx.stack.Lookup(required); !exists {
 				return nil, fmt.Errorf("required attribute '%s' not provided", required)
 			}
 		}
 
 		// Evaluate v-html if attribute is provided
-		if err := v.evalVHtml(ctx, nodes[0]); err != nil {
-			return nil, err
-		}
-
-		// Check if v-html was evaluated (internal attribute set)
-		hasVHtml := false
-		for _, attr := range node.Attr {
-			if attr.Key == "data-v-html-content" {
-				hasVHtml = true
-				break
+		if hasVHtml(node) {
+			htmlNode := helpers.ShallowCloneWithAttrs(node)
+			if err := v.evalVHtml(ctx, htmlNode); err != nil {
+				return nil, err
 			}
-		}
 
-		// If v-html was evaluated, return the template node for rendering to output its content
-		if hasVHtml {
-			return nodes, nil
+			// If v-html was evaluated, return the template node for rendering to output its content
+			if helpers.HasAttr(htmlNode, "data-v-html-content") || helpers.HasAttr(htmlNode, "data-v-html-nodes") {
+				return []*html.Node{htmlNode}, nil
+			}
 		}
 
 		// Evaluate attributes and set them in current scope
@@ -102,7 +84,7 @@ func (v *Vue) evalTemplate(ctx VueContext, nodes []*html.Node, componentData map
 			val := strings.TrimSpace(attr.Val)
 
 			// Skip directive attributes
-			if strings.HasPrefix(key, "v-") {
+			if strings.HasPrefix(key, "v-") || key == "inherit-attrs" {
 				continue
 			}
 
@@ -110,52 +92,18 @@ func (v *Vue) evalTemplate(ctx VueContext, nodes []*html.Node, componentData map
 			if strings.HasPrefix(key, ":") {
 				boundName := key[1:]
 				// Skip special attributes like :required
-				if boundName == "require" || boundName == "required" {
-					continue
-				}
-
-				// Try evalPipe first to support funcmap functions like jsonFile()
-				pipe := parsePipeExpr(val)
-				result, err := v.evalPipe(ctx, pipe)
-				if err == nil {
-					ctx.stack.Set(boundName, result)
-					continue
-				}
-
-				// Fall back to expression evaluator for literals and arithmetic expressions
-				result, err = v.exprEval.Eval(val, ctx.ExprEnv())
-				if err == nil {
-					ctx.stack.Set(boundName, result)
+				if boundName == "require" || boundName == "required" || boundName == "props" {
 					continue
 				}
 
-				// Final fallback to variable resolution
-				valResolved, ok := ctx.stack.Resolve(val)
-				if ok {
-					ctx.stack.Set(boundName, valResolved)
-				} else {
-					ctx.stack.Set(boundName, nil)
+				if err := v.setTemplateBinding(ctx, boundName, val); err != nil {
+					return nil, err
 				}
 				continue
 			}
 			if strings.HasPrefix(key, "v-bind:") {
-				boundName := key[7:]
-				pipe := parsePipeExpr(val)
-				result, err := v.evalPipe(ctx, pipe)
-				if err == nil {
-					ctx.stack.Set(boundName, result)
-					continue
-				}
-				result, err = v.exprEval.Eval(val, ctx.ExprEnv())
-				if err == nil {
-					ctx.stack.Set(boundName, result)
-					continue
-				}
-				valResolved, ok := ctx.stack.Resolve(val)
-				if ok {
-					ctx.stack.Set(boundName, valResolved)
-				} else {
-					ctx.stack.Set(boundName, nil)
+				if err := v.setTemplateBinding(ctx, key[7:], val); err != nil {
+					return nil, err
 				}
 				continue
 			}
@@ -182,3 +130,61 @@ func (v *Vue) evalTemplate(ctx VueContext, nodes []*html.Node, componentData map
 	// If no template tag, return nodes as-is
 	return nodes, nil
 }
+
+// setTemplateBinding evaluates a bound template attribute and sets the result in the current scope.
+func (v *Vue) setTemplateBinding(ctx VueContext, boundName, val string) error {
+	// Try evalPipe first to support funcmap functions like jsonFile()
+	pipe := *v.pipe(val)
+	result, err := v.evalPipe(ctx, pipe)
+	if err == nil {
+		ctx.stack.Set(boundName, result)
+		return nil
+	}
+	if isFatalError(err) {
+		return err
+	}
+
+	// Fall back to expression evaluator for literals and arithmetic expressions
+	result, err = v.evalExpr(ctx, val)
+	if err == nil {
+		ctx.stack.Set(boundName, result)
+		return nil
+	}
+	if isFatalError(err) {
+		return err
+	}
+
+	// Final fallback to variable resolution
+	valResolved, ok := ctx.stack.Resolve(val)
+	if !ok {
+		if err := v.checkPath(ctx, val); err != nil {
+			return err
+		}
+	}
+	ctx.stack.Set(boundName, valResolved)
+	return nil
+}
+
+// componentProps evaluates the attributes of a component tag into props,
+// and returns the evaluated attributes as they would render.
+// String values holding a JSON object or array, e.g. `data="{...}"`, are decoded.
+func (v *Vue) componentProps(ctx VueContext, node *html.Node) (map[string]any, []html.Attribute, error) {
+	// Bind attributes on a copy, the source node belongs to the compiled template
+	evaluated := helpers.ShallowCloneWithAttrs(node)
+	vars, err := v.evalAttributes(ctx, evaluated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for k, v := range vars {
+		if vs, ok := v.(string); ok {
+			if strings.HasPrefix(vs, "{") || strings.HasPrefix(vs, "[") {
+				var out any
+				if err := json.Unmarshal([]byte(vs), &out); err == nil {
+					vars[k] = out
+				}
+			}
+		}
+	}
+	return vars, evaluated.Attr, nil
+}
@@ -32,7 +32,7 @@ func TestRequiredAttributeError(t *testing.T) {
 	var buf bytes.Buffer
 	err := vue.Render(t.Context(), &buf, "required-error-test/page.vuego", data)
 
-	assert.Equal(t, "error in required-error-test/component.vuego (included from required-error-test/page.vuego): required attribute 'title' not provided", err.Error())
+	assert.Equal(t, "required-error-test/page.vuego:7:3: error in required-error-test/component.vuego (included from required-error-test/page.vuego): required attribute 'title' not provided", err.Error())
 }
 
 // TestRootLevelTemplateRequired tests that a root-level template with :required attribute
@@ -47,7 +47,7 @@ func TestRootLevelTemplateRequired(t *testing.T) {
 	var buf bytes.Buffer
 	err := vue.Render(t.Context(), &buf, "root-template-required/page.vuego", data)
 
-	assert.Equal(t, "required attribute 'title' not provided", err.Error())
+	assert.Equal(t, "root-template-required/page.vuego:7:3: required attribute 'title' not provided", err.Error())
 }
 
 // TestRootLevelTemplateRequiredSuccess tests that a root-level template with :required attribute
@@ -66,6 +66,24 @@ func TestRootLevelTemplateRequiredSuccess(t *testing.T) {
 	assert.Contains(t, buf.String(), "<h1>My Page Title</h1>")
 }
 
+// TestRootLevelTemplateRequiredStruct tests that a :required attribute is
+// satisfied by a field of struct data, which is looked up in scope.
+func TestRootLevelTemplateRequiredStruct(t *testing.T) {
+	vue := vuego.NewVue(os.DirFS("testdata"))
+
+	data := struct {
+		Title string `json:"title"`
+	}{
+		Title: "My Page Title",
+	}
+
+	var buf bytes.Buffer
+	err := vue.Render(t.Context(), &buf, "root-template-required/page.vuego", data)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "<h1>My Page Title</h1>")
+}
+
 // TestRequiredCSVExpansion tests that :required supports CSV format
 // e.g., :required="name,title,author" expands to multiple required fields.
 func TestRequiredCSVExpansion(t *testing.T) {
@@ -79,7 +97,7 @@ func TestRequiredCSVExpansion(t *testing.T) {
 	var buf bytes.Buffer
 	err := vue.Render(t.Context(), &buf, "required-error-test/page.vuego", data)
 
-	assert.Equal(t, "error in required-error-test/component.vuego (included from required-error-test/page.vuego): required attribute 'author' not provided", err.Error())
+	assert.Equal(t, "required-error-test/page.vuego:7:3: error in required-error-test/component.vuego (included from required-error-test/page.vuego): required attribute 'author' not provided", err.Error())
 }
 
 // TestTemplateSimpleAttribute tests that a simple bound attribute on a template
@@ -9,8 +9,19 @@ import (
 	"github.com/titpetric/vuego/internal/helpers"
 )
 
+// hasVHtml reports whether n sets its content with v-html or v-html-safe.
+func hasVHtml(n *html.Node) bool {
+	return helpers.GetAttr(n, "v-html") != "" || helpers.GetAttr(n, "v-html-safe") != ""
+}
+
 func (v *Vue) evalVHtml(ctx VueContext, n *html.Node) error {
 	expr := helpers.GetAttr(n, "v-html")
+	// v-html-safe sanitizes the content with the default policy
+	safe := false
+	if expr == "" {
+		expr = helpers.GetAttr(n, "v-html-safe")
+		safe = true
+	}
 	if expr == "" {
 		return nil
 	}
@@ -19,22 +30,40 @@ func (v *Vue) evalVHtml(ctx VueContext, n *html.Node) error {
 	if !ok {
 		// v-html may be a function call like "file(src)"
 		var err error
-		if strings.Contains(expr, "|") || helpers.IsFunctionCall(expr) || helpers.IsComplexExpr(expr) {
-			pipe := parsePipeExpr(expr)
+		if strings.ContainsAny(expr, "|(") || helpers.IsComplexExpr(expr) {
+			pipe := *v.pipe(expr)
 			val, err = v.evalPipe(ctx, pipe)
 			if err != nil {
-				return fmt.Errorf("in expression '{{ %s }}': %w", expr, err)
+				return &exprError{expr: expr, err: err}
 			}
 			ok = true
 		}
 	}
 
 	if !ok {
+		return v.checkPath(ctx, expr)
+	}
+
+	// Layout content is already evaluated DOM, splice it in as children
+	if content, ok := val.(*layoutContent); ok {
+		n.Attr = append(n.Attr, html.Attribute{Key: "data-v-html-nodes", Val: ""})
+		n.FirstChild = nil
+		n.LastChild = nil
+		for _, c := range content.clone() {
+			n.AppendChild(c)
+		}
 		return nil
 	}
 
 	// Evaluate v-html expression to its string value and store in internal attribute
 	htmlStr := fmt.Sprint(val)
+	if safe {
+		policy, err := v.sanitizePolicy("")
+		if err != nil {
+			return err
+		}
+		htmlStr = string(policy.Sanitize(htmlStr))
+	}
 	n.Attr = append(n.Attr, html.Attribute{Key: "data-v-html-content", Val: htmlStr})
 
 	// Clear children - v-html content will be output directly during rendering
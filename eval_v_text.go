@@ -1,7 +1,6 @@
 package vuego
 
 import (
-	"fmt"
 	"html"
 	"strings"
 
@@ -22,22 +21,22 @@ func (v *Vue) evalVText(ctx VueContext, n *htmlnode.Node) error {
 	if !ok {
 		// v-text may be a function call like "file(src)"
 		var err error
-		if strings.Contains(expr, "|") || helpers.IsFunctionCall(expr) || helpers.IsComplexExpr(expr) {
-			pipe := parsePipeExpr(expr)
+		if strings.ContainsAny(expr, "|(") || helpers.IsComplexExpr(expr) {
+			pipe := *v.pipe(expr)
 			val, err = v.evalPipe(ctx, pipe)
 			if err != nil {
-				return fmt.Errorf("in expression '{{ %s }}': %w", expr, err)
+				return &exprError{expr: expr, err: err}
 			}
 			ok = true
 		}
 	}
 
 	if !ok {
-		return nil
+		return v.checkPath(ctx, expr)
 	}
 
 	// Evaluate v-text expression to its string value and escape for HTML
-	textStr := fmt.Sprint(val)
+	textStr := formatValue(val)
 	escapedStr := html.EscapeString(textStr)
 	n.Attr = append(n.Attr, htmlnode.Attribute{Key: "data-v-text-content", Val: escapedStr})
 
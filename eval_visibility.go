@@ -17,12 +17,18 @@ func (v *Vue) evalVShow(ctx VueContext, n *html.Node) error {
 	}
 
 	// Evaluate the expression using the same approach as v-if
-	val, err := v.exprEval.Eval(vShowExpr, ctx.ExprEnv())
+	val, err := v.evalExpr(ctx, vShowExpr)
 	if err != nil {
+		if isFatalError(err) {
+			return err
+		}
 		// Fall back to stack resolution for simple variable references
 		var ok bool
 		val, ok = ctx.stack.Resolve(vShowExpr)
 		if !ok {
+			if err := v.checkPath(ctx, vShowExpr); err != nil {
+				return err
+			}
 			val = false
 		}
 	}
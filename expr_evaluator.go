@@ -6,24 +6,43 @@ import (
 	"sync"
 
 	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/conf"
+	"github.com/expr-lang/expr/parser"
 	"github.com/expr-lang/expr/vm"
+	"github.com/expr-lang/expr/vm/runtime"
+
+	ireflect "github.com/titpetric/vuego/internal/reflect"
 )
 
+// exprOptions are the options expressions are compiled with.
+var exprOptions = []expr.Option{
+	expr.AllowUndefinedVariables(),
+	expr.DisableBuiltin("count"),
+	expr.Function(memberFunc, fetchMember),
+	expr.Patch(memberPatcher{}),
+}
+
 // ExprEvaluator wraps expr for evaluating boolean and interpolated expressions.
 // It caches compiled programs to avoid recompilation.
 type ExprEvaluator struct {
 	mu       sync.RWMutex
 	programs map[string]*vm.Program
+	failed   map[string]error
+	refs     map[string][][]string
 }
 
 // NewExprEvaluator creates a new ExprEvaluator with an empty cache.
 func NewExprEvaluator() *ExprEvaluator {
 	return &ExprEvaluator{
 		programs: make(map[string]*vm.Program),
+		failed:   make(map[string]error),
+		refs:     make(map[string][][]string),
 	}
 }
 
-// Eval evaluates an expression against the given environment (stack).
+// Eval evaluates an expression against the given environment, a map or a
+// value with a `Lookup(name string) (any, bool)` method, like a scope chain.
 // It returns the result value and any error.
 // The expression can contain:
 //   - Variable references: item, item.title, items[0]
@@ -31,11 +50,9 @@ func NewExprEvaluator() *ExprEvaluator {
 //   - Boolean operations: &&, ||, !
 //   - Function calls: len(items), isActive(v)
 //   - Literals: 42, "text", true, false.
-func (e *ExprEvaluator) Eval(expression string, env map[string]any) (any, error) {
-	expression = strings.ReplaceAll(expression, "===", "==")
-
+func (e *ExprEvaluator) Eval(expression string, env any) (any, error) {
 	// Get or compile the program
-	prog, err := e.getProgram(expression)
+	prog, err := e.prepare(expression)
 	if err != nil {
 		return nil, err
 	}
@@ -48,19 +65,35 @@ func (e *ExprEvaluator) Eval(expression string, env map[string]any) (any, error)
 	return result, nil
 }
 
+// prepare normalizes an expression and returns its compiled program.
+// Templates call it at compile time so renders only hit the cache.
+func (e *ExprEvaluator) prepare(expression string) (*vm.Program, error) {
+	return e.getProgram(strings.ReplaceAll(expression, "===", "=="))
+}
+
 // getProgram returns a cached compiled program or compiles a new one.
+// Compile failures are cached as well, since callers fall back to other
+// evaluation strategies and would otherwise recompile on every render.
 func (e *ExprEvaluator) getProgram(expression string) (*vm.Program, error) {
 	e.mu.RLock()
 	if prog, ok := e.programs[expression]; ok {
 		e.mu.RUnlock()
 		return prog, nil
 	}
+	if err, ok := e.failed[expression]; ok {
+		e.mu.RUnlock()
+		return nil, err
+	}
 	e.mu.RUnlock()
 
 	// Compile the expression
-	prog, err := expr.Compile(expression, expr.AllowUndefinedVariables(), expr.DisableBuiltin("count"))
+	prog, err := expr.Compile(expression, exprOptions...)
 	if err != nil {
-		return nil, fmt.Errorf("compile error: %w", err)
+		err = fmt.Errorf("compile error: %w", err)
+		e.mu.Lock()
+		e.failed[expression] = err
+		e.mu.Unlock()
+		return nil, err
 	}
 
 	// Cache it
@@ -71,9 +104,130 @@ func (e *ExprEvaluator) getProgram(expression string) (*vm.Program, error) {
 	return prog, nil
 }
 
+// references returns the variable paths the expression reads from its environment.
+func (e *ExprEvaluator) references(expression string) ([][]string, error) {
+	expression = strings.ReplaceAll(expression, "===", "==")
+
+	e.mu.RLock()
+	refs, ok := e.refs[expression]
+	e.mu.RUnlock()
+	if ok {
+		return refs, nil
+	}
+
+	// References are read from the parsed expression, before member access
+	// is patched, see memberPatcher.
+	tree, err := parser.ParseWithConfig(expression, exprConfig)
+	if err != nil {
+		return nil, err
+	}
+	refs = exprReferences(tree.Node)
+
+	e.mu.Lock()
+	e.refs[expression] = refs
+	e.mu.Unlock()
+
+	return refs, nil
+}
+
 // ClearCache clears the program cache (useful for testing or memory management).
 func (e *ExprEvaluator) ClearCache() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	e.programs = make(map[string]*vm.Program)
+	e.failed = make(map[string]error)
+	e.refs = make(map[string][][]string)
+}
+
+// exprConfig is the parser configuration of exprOptions.
+var exprConfig = func() *conf.Config {
+	c := conf.CreateNew()
+	for _, opt := range exprOptions {
+		opt(c)
+	}
+	return c
+}()
+
+// memberFunc is the function variable and member access is patched to call.
+const memberFunc = "$member"
+
+// lookupEnv is an expression environment that looks up variables on access.
+type lookupEnv interface {
+	Lookup(name string) (any, bool)
+}
+
+// memberPatcher rewrites variables like `user` and member access like
+// `user.name` into calls of fetchMember. Variables are looked up in the
+// environment as they are accessed, so it can be a scope chain instead of a
+// map, and member access resolves JSON tags and methods with pointer
+// receivers, which expr doesn't for untyped environments. Optional chains
+// like `user?.name` are left to expr.
+type memberPatcher struct{}
+
+// Visit patches a single node; expr walks the nodes depth first.
+func (memberPatcher) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode:
+		if n.Value == "$env" || n.Value == memberFunc {
+			return
+		}
+		ast.Patch(node, &ast.CallNode{
+			Callee:    &ast.IdentifierNode{Value: memberFunc},
+			Arguments: []ast.Node{&ast.IdentifierNode{Value: "$env"}, &ast.StringNode{Value: n.Value}},
+		})
+	case *ast.VariableDeclaratorNode:
+		// Variables declared with let are not in the environment
+		ast.Walk(&n.Expr, variableRestorer{name: n.Name})
+	case *ast.MemberNode:
+		if n.Optional {
+			return
+		}
+		if _, ok := n.Property.(*ast.StringNode); !ok {
+			return
+		}
+		ast.Patch(node, &ast.CallNode{
+			Callee:    &ast.IdentifierNode{Value: memberFunc},
+			Arguments: []ast.Node{n.Node, n.Property},
+		})
+	}
+}
+
+// variableRestorer reverts the patched lookups of a let-declared variable.
+type variableRestorer struct {
+	name string
+}
+
+// Visit restores a single patched variable lookup.
+func (r variableRestorer) Visit(node *ast.Node) {
+	call, ok := (*node).(*ast.CallNode)
+	if !ok || len(call.Arguments) != 2 {
+		return
+	}
+	if callee, ok := call.Callee.(*ast.IdentifierNode); !ok || callee.Value != memberFunc {
+		return
+	}
+	env, ok := call.Arguments[0].(*ast.IdentifierNode)
+	name, isName := call.Arguments[1].(*ast.StringNode)
+	if ok && isName && env.Value == "$env" && name.Value == r.name {
+		ast.Patch(node, &ast.IdentifierNode{Value: r.name})
+	}
+}
+
+// fetchMember returns a variable from the environment, or the member of a
+// value. Methods, including methods with pointer receivers, and struct fields
+// by name or JSON tag are resolved first, other values are fetched by expr.
+// Undefined variables are nil.
+func fetchMember(params ...any) (any, error) {
+	from, name := params[0], params[1].(string)
+	switch from := from.(type) {
+	case lookupEnv:
+		val, _ := from.Lookup(name)
+		return val, nil
+	case map[string]any:
+		return from[name], nil
+	}
+	if val, ok := ireflect.Member(from, name); ok {
+		return val, nil
+	}
+	return runtime.Fetch(from, name), nil
 }
@@ -58,6 +58,18 @@ func TestExprEvaluator_Eval(t *testing.T) {
 			env:        map[string]any{"items": []int{1, 2, 3}},
 			expected:   3,
 		},
+		{
+			name:       "let declaration shadows a variable",
+			expression: "let x = y * 2; let y = x + 1; x + y",
+			env:        map[string]any{"x": 1, "y": 5},
+			expected:   21,
+		},
+		{
+			name:       "closure over items",
+			expression: "filter(items, # > limit)",
+			env:        map[string]any{"items": []int{1, 2, 3}, "limit": 1},
+			expected:   []any{2, 3},
+		},
 		{
 			name:       "string literal",
 			expression: "'hello'",
@@ -114,3 +126,24 @@ func TestExprEvaluator_ClearCache(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 15, result2)
 }
+
+func TestExprEvaluator_EvalStack(t *testing.T) {
+	eval := vuego.NewExprEvaluator()
+
+	stack := vuego.NewStack(map[string]any{"factor": 2, "item": "root"})
+	stack.Push(nil)
+	stack.Set("item", map[string]any{"n": 21})
+
+	result, err := eval.Eval("item.n * factor", stack)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+
+	stack.Pop()
+	result, err = eval.Eval("item + string(factor)", stack)
+	assert.NoError(t, err)
+	assert.Equal(t, "root2", result)
+
+	result, err = eval.Eval("missing == nil", stack)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+}
@@ -0,0 +1,215 @@
+package vuego
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/titpetric/vuego/internal/helpers"
+)
+
+// FragmentCache stores rendered fragments for the v-cache directive.
+// Implementations must be safe for concurrent use.
+type FragmentCache interface {
+	// Get returns the fragment stored under key.
+	Get(key string) ([]byte, bool)
+	// Set stores a fragment under key. A zero ttl stores it without expiry.
+	Set(key string, fragment []byte, ttl time.Duration)
+	// Purge removes the fragments with keys starting with prefix.
+	// An empty prefix removes all fragments.
+	Purge(prefix string)
+}
+
+// DefaultFragmentCacheSize is the number of fragments kept by the default fragment cache.
+const DefaultFragmentCacheSize = 1024
+
+// MemoryFragmentCache is an in-memory FragmentCache that evicts the least
+// recently used fragment when it is full.
+type MemoryFragmentCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type fragmentEntry struct {
+	key      string
+	fragment []byte
+	expires  time.Time
+}
+
+// NewMemoryFragmentCache returns an in-memory fragment cache holding up to size fragments.
+func NewMemoryFragmentCache(size int) *MemoryFragmentCache {
+	if size <= 0 {
+		size = DefaultFragmentCacheSize
+	}
+	return &MemoryFragmentCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the fragment stored under key, if it has not expired.
+func (c *MemoryFragmentCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*fragmentEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return entry.fragment, true
+}
+
+// Set stores a fragment under key, evicting the least recently used fragment if the cache is full.
+func (c *MemoryFragmentCache) Set(key string, fragment []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &fragmentEntry{key: key, fragment: fragment}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*fragmentEntry).key)
+	}
+}
+
+// Purge removes the fragments with keys starting with prefix.
+func (c *MemoryFragmentCache) Purge(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.lru.Remove(el)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Len returns the number of cached fragments.
+func (c *MemoryFragmentCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// WithFragmentCache returns a LoadOption that sets the store used by v-cache.
+// By default, fragments are kept in a MemoryFragmentCache.
+func WithFragmentCache(cache FragmentCache) LoadOption {
+	return func(vue *Vue) {
+		vue.fragments = cache
+	}
+}
+
+// PurgeFragments removes the cached fragments with keys starting with prefix.
+func (v *Vue) PurgeFragments(prefix string) {
+	v.fragments.Purge(prefix)
+}
+
+// fragmentRenderer serializes cached fragments. The output is parsed back into
+// nodes on a cache hit, so it must be exact.
+var fragmentRenderer = NewFaithfulRenderer()
+
+// evalCached evaluates an element with a `v-cache="key"` attribute. The rendered
+// element is stored in the fragment cache under the evaluated key and reused
+// by later renders, for the duration of an optional `cache-ttl="5m"` attribute.
+// The render function evaluates the element itself, and is skipped on a cache hit.
+func (v *Vue) evalCached(ctx VueContext, node *html.Node, render func(VueContext) ([]*html.Node, error)) ([]*html.Node, error) {
+	expr := helpers.GetAttr(node, "v-cache")
+	key, err := v.evalDirectiveValue(ctx, expr)
+	if err != nil {
+		return nil, &attrError{key: "v-cache", name: "v-cache", err: err}
+	}
+
+	var ttl time.Duration
+	if val := helpers.GetAttr(node, "cache-ttl"); val != "" {
+		if ttl, err = time.ParseDuration(val); err != nil {
+			return nil, &attrError{key: "cache-ttl", name: "cache-ttl", err: err}
+		}
+	}
+
+	ctx.cacheNode = node
+	if key == nil || key == "" {
+		result, err := render(ctx)
+		return removeCacheTTL(result), err
+	}
+	cacheKey := fmt.Sprint(key)
+
+	if fragment, ok := v.fragments.Get(cacheKey); ok {
+		return parseFragment(node, fragment)
+	}
+
+	result, err := render(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result = removeCacheTTL(result)
+
+	var buf bytes.Buffer
+	if err := fragmentRenderer.Render(ctx.Context(), &buf, result); err != nil {
+		return nil, err
+	}
+	v.fragments.Set(cacheKey, buf.Bytes(), ttl)
+	return result, nil
+}
+
+// removeCacheTTL removes the cache-ttl attribute from the rendered v-cache element.
+// Other elements keep a cache-ttl attribute, as it only configures v-cache.
+func removeCacheTTL(nodes []*html.Node) []*html.Node {
+	for _, node := range nodes {
+		if node.Type == html.ElementNode && helpers.HasAttr(node, "v-cache") {
+			helpers.RemoveAttr(node, "cache-ttl")
+		}
+	}
+	return nodes
+}
+
+// fragmentContext returns the parent element a cached element is parsed in,
+// as table elements are dropped outside of a table.
+func fragmentContext(tag string) *html.Node {
+	var parent atom.Atom
+	switch tag {
+	case "tr":
+		parent = atom.Tbody
+	case "td", "th":
+		parent = atom.Tr
+	case "tbody", "thead", "tfoot", "caption", "colgroup":
+		parent = atom.Table
+	case "col":
+		parent = atom.Colgroup
+	default:
+		return helpers.GetBodyNode()
+	}
+	return &html.Node{Type: html.ElementNode, Data: parent.String(), DataAtom: parent}
+}
+
+// parseFragment parses a cached fragment of node back into nodes.
+func parseFragment(node *html.Node, fragment []byte) ([]*html.Node, error) {
+	return html.ParseFragment(bytes.NewReader(fragment), fragmentContext(node.Data))
+}
@@ -0,0 +1,82 @@
+package vuego_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestFragmentCache(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<div><nav v-cache="'menu:' + locale"><a v-for="item in items">{{ item }}</a><span>{{ tick() }}</span></nav><p>{{ tick() }}</p></div>`)},
+	}
+
+	var ticks int
+	cache := vuego.NewMemoryFragmentCache(10)
+	tpl := vuego.NewFS(templateFS,
+		vuego.WithRenderer(vuego.NewFaithfulRenderer()),
+		vuego.WithFragmentCache(cache),
+		vuego.WithFuncs(vuego.FuncMap{"tick": func() int {
+			ticks++
+			return ticks
+		}}),
+	)
+
+	render := func(locale string, items ...string) string {
+		var buf bytes.Buffer
+		data := map[string]any{"locale": locale, "items": items}
+		assert.NoError(t, tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf))
+		return buf.String()
+	}
+
+	assert.Equal(t, `<div><nav><a>Home</a><a>About</a><span>1</span></nav><p>2</p></div>`, render("en", "Home", "About"))
+	assert.Equal(t, `<div><nav><a>Home</a><a>About</a><span>1</span></nav><p>3</p></div>`, render("en", "Other"))
+	assert.Equal(t, `<div><nav><a>Domov</a><span>4</span></nav><p>5</p></div>`, render("sl", "Domov"))
+	assert.Equal(t, 2, cache.Len())
+
+	cache.Purge("menu:e")
+	assert.Equal(t, 1, cache.Len())
+	assert.Equal(t, `<div><nav><a>Other</a><span>6</span></nav><p>7</p></div>`, render("en", "Other"))
+}
+
+func TestFragmentCache_Directives(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<table><tr v-for="row in rows" v-cache="'row:' + string(row.id)"><td>{{ row.name }}</td></tr></table>` +
+			`<p v-if="show" v-cache="'if'">{{ text }}</p><p v-else>else</p>` +
+			`<i v-cache="'ttl'" cache-ttl="1ns">{{ text }}</i>` +
+			`<b v-cache="''">{{ text }}</b>` +
+			`<time cache-ttl="5m">{{ text }}</time>`)},
+	}
+	tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+	render := func(name, text string) string {
+		var buf bytes.Buffer
+		data := map[string]any{
+			"rows": []map[string]any{{"id": 1, "name": name}, {"id": 2, "name": name}},
+			"show": true,
+			"text": text,
+		}
+		assert.NoError(t, tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf))
+		return buf.String()
+	}
+
+	assert.Equal(t, `<table><tbody><tr><td>a &amp; b</td></tr><tr><td>a &amp; b</td></tr></tbody></table><p>one</p><i>one</i><b>one</b><time cache-ttl="5m">one</time>`, render("a & b", "one"))
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, `<table><tbody><tr><td>a &amp; b</td></tr><tr><td>a &amp; b</td></tr></tbody></table><p>one</p><i>two</i><b>two</b><time cache-ttl="5m">two</time>`, render("c", "two"))
+}
+
+func TestFragmentCache_InvalidTTL(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<div v-cache="'k'" cache-ttl="soon">x</div>`)},
+	}
+	tpl := vuego.NewFS(templateFS)
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Render(t.Context(), &buf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error evaluating attr cache-ttl")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html"
 	"io/fs"
+	"path"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -43,6 +44,7 @@ func (v *Vue) DefaultFuncMap() FuncMap {
 		"trim":       trimFunc,
 		"escape":     escapeFunc,
 		"int":        intFunc,
+		"range":      rangeFunc,
 		"string":     stringFunc,
 		"json":       jsonFunc,
 		"jsonPretty": jsonPrettyFunc,
@@ -50,6 +52,7 @@ func (v *Vue) DefaultFuncMap() FuncMap {
 		"file":       fileFunc(v),
 		"jsonFile":   jsonFileFunc(v),
 		"yamlFile":   yamlFileFunc(v),
+		"sanitize":   sanitizeFunc(v),
 	}
 }
 
@@ -105,6 +108,13 @@ func parsePipeExpr(expr string) pipeExpr {
 				}},
 			}
 		}
+		// Method calls like "user.FullName()" are expressions
+		if strings.Contains(trimmed, "(") {
+			return pipeExpr{
+				initial:  "",
+				segments: []pipeSegment{{typ: segmentExpr, expr: trimmed}},
+			}
+		}
 		// Just a simple variable reference
 		return pipeExpr{initial: trimmed}
 	}
@@ -114,7 +124,13 @@ func parsePipeExpr(expr string) pipeExpr {
 
 	result := pipeExpr{
 		initial:  firstPart,
-		segments: make([]pipeSegment, 0, len(parts)-1),
+		segments: make([]pipeSegment, 0, len(parts)),
+	}
+
+	// A call like "fn()" or "user.FullName()" is evaluated, not resolved
+	if strings.Contains(firstPart, "(") {
+		result.initial = ""
+		result.segments = append(result.segments, classifySegment(firstPart))
 	}
 
 	for i := 1; i < len(parts); i++ {
@@ -214,6 +230,9 @@ func (v *Vue) evalPipe(ctx VueContext, expr pipeExpr) (any, error) {
 	var ok bool
 	val, ok = ctx.stack.Resolve(expr.initial)
 	if !ok {
+		if err := v.checkPath(ctx, expr.initial); err != nil {
+			return nil, err
+		}
 		if len(expr.segments) > 0 {
 			val = nil // Pass nil to first segment filter
 		} else {
@@ -239,16 +258,30 @@ func (v *Vue) evalPipe(ctx VueContext, expr pipeExpr) (any, error) {
 func (v *Vue) evalSegment(ctx VueContext, seg pipeSegment, input any, isFirst, fromInitial bool) (any, error) {
 	switch seg.typ {
 	case segmentFilter:
-		return v.evalFilter(ctx, seg, input, isFirst, fromInitial)
+		if v.limits == nil || v.limits.MaxExprTime <= 0 {
+			return v.evalFilter(ctx, seg, input, isFirst, fromInitial)
+		}
+		filterCtx, cancel := v.withExprDeadline(ctx)
+		defer cancel()
+
+		start := time.Now()
+		val, err := v.evalFilter(filterCtx, seg, input, isFirst, fromInitial)
+		if limitErr := v.timeExpr(ctx, seg.expr, start); limitErr != nil {
+			return nil, limitErr
+		}
+		return val, err
 	case segmentExpr:
 		// Use expr library with . representing the input value
-		env := ctx.ExprEnv()
 		if input != nil {
-			env["."] = input
-			defer delete(env, ".")
+			ctx.stack.Push(nil)
+			ctx.stack.Set(".", input)
+			defer ctx.stack.Pop()
 		}
-		result, err := v.exprEval.Eval(seg.expr, env)
+		result, err := v.evalExpr(ctx, seg.expr)
 		if err != nil {
+			if isFatalError(err) {
+				return nil, err
+			}
 			return nil, fmt.Errorf("in expression '%s': %w", seg.expr, err)
 		}
 		return result, nil
@@ -262,6 +295,14 @@ func (v *Vue) evalSegment(ctx VueContext, seg pipeSegment, input any, isFirst, f
 func (v *Vue) evalFilter(ctx VueContext, seg pipeSegment, input any, isFirst, fromInitial bool) (any, error) {
 	fn, exists := v.funcMap[seg.name]
 	if !exists {
+		// Functions and methods of the data, like `{{ FullName() }}`
+		fn, exists = ctx.exprEnv().Lookup(seg.name)
+		exists = exists && reflect.ValueOf(fn).Kind() == reflect.Func
+	}
+	if !exists {
+		if v.strict && helpers.IsIdentifier(seg.name) {
+			return nil, newStrictError(ctx, seg.expr, "undefined function '%s'", seg.name)
+		}
 		return nil, fmt.Errorf("function '%s' not found", seg.name)
 	}
 
@@ -483,6 +524,13 @@ func convertValue(val reflect.Value, targetType reflect.Type) (reflect.Value, bo
 		return reflect.ValueOf(fmt.Sprintf("%t", val.Bool())), true
 	}
 
+	// Handle fmt.Stringer to string (e.g. layout content)
+	if targetType.Kind() == reflect.String && val.CanInterface() {
+		if stringer, ok := val.Interface().(fmt.Stringer); ok {
+			return reflect.ValueOf(stringer.String()).Convert(targetType), true
+		}
+	}
+
 	return reflect.Value{}, false
 }
 
@@ -504,8 +552,20 @@ func fileFunc(v *Vue) func(*VueContext, string) (any, error) {
 		if err != nil {
 			return "", err
 		}
-		return string(d), nil
+		return fileContent(filename, d), nil
+	}
+}
+
+// fileContent returns the content of a template file as JS or CSS, going by its
+// extension, so scripts and styles can include it as is. Other files are strings.
+func fileContent(filename string, data []byte) any {
+	switch path.Ext(filename) {
+	case ".js", ".mjs", ".json":
+		return JS(data)
+	case ".css", ".less":
+		return CSS(data)
 	}
+	return string(data)
 }
 
 func jsonFileFunc(v *Vue) func(*VueContext, string) (any, error) {
@@ -664,22 +724,51 @@ func intFunc(v any) any {
 	return 0
 }
 
+// rangeFunc returns the integers from start up to, but not including, end:
+// range(end), range(start, end) or range(start, end, step).
+// A negative step counts down from start to end. The result is a slice,
+// so v-for knows its length up front.
+func rangeFunc(args ...int) ([]int, error) {
+	start, end, step := 0, 0, 1
+	switch len(args) {
+	case 1:
+		end = args[0]
+	case 2:
+		start, end = args[0], args[1]
+	case 3:
+		start, end, step = args[0], args[1], args[2]
+	default:
+		return nil, fmt.Errorf("range expects 1 to 3 arguments, got %d", len(args))
+	}
+	if step == 0 {
+		return nil, fmt.Errorf("range step must not be zero")
+	}
+
+	result := []int{}
+	for i := start; (step > 0 && i < end) || (step < 0 && i > end); i += step {
+		result = append(result, i)
+	}
+	return result, nil
+}
+
 func stringFunc(v any) any {
-	return fmt.Sprint(v)
+	return formatValue(v)
 }
 
-func jsonFunc(v any) (string, error) {
+// jsonFunc encodes v as JSON. The encoder escapes <, > and &, so the
+// result is safe to write into a script as is.
+func jsonFunc(v any) (JS, error) {
 	b, err := json.Marshal(v)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to JSON: %w", err)
 	}
-	return string(b), nil
+	return JS(b), nil
 }
 
-func jsonPrettyFunc(v any) (string, error) {
+func jsonPrettyFunc(v any) (JS, error) {
 	b, err := json.MarshalIndent(v, "  ", "")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to JSON: %w", err)
 	}
-	return string(b), nil
+	return JS(b), nil
 }
@@ -332,7 +332,7 @@ func TestVue_Funcs_MissingFilter(t *testing.T) {
 	var buf bytes.Buffer
 	err := vue.Render(t.Context(), &buf, "test.vuego", data)
 	assert.Error(t, err)
-	assert.Equal(t, "in test.vuego: in expression '{{ name | nonexistent }}': function 'nonexistent' not found", err.Error())
+	assert.Equal(t, "test.vuego:1:4: in expression '{{ name | nonexistent }}': function 'nonexistent' not found", err.Error())
 }
 
 func TestVue_Funcs_VForWithFilters(t *testing.T) {
@@ -472,7 +472,7 @@ func TestVue_Funcs_ErrorReturn(t *testing.T) {
 	var buf bytes.Buffer
 	err := vue.Render(t.Context(), &buf, "test.vuego", data)
 	assert.Error(t, err)
-	assert.Equal(t, "in test.vuego: in expression '{{ divide(10, 0) }}': divide(): division by zero", err.Error())
+	assert.Equal(t, "test.vuego:1:4: in expression '{{ divide(10, 0) }}': divide(): division by zero", err.Error())
 }
 
 func TestVue_Funcs_NoReturnValue(t *testing.T) {
@@ -519,7 +519,7 @@ func TestVue_Funcs_TypeConversionError(t *testing.T) {
 	var buf bytes.Buffer
 	err := vue.Render(t.Context(), &buf, "test.vuego", data)
 	assert.Error(t, err)
-	assert.Equal(t, "in test.vuego: in expression '{{ items | double }}': double(): cannot convert argument 0 from []string to int", err.Error())
+	assert.Equal(t, "test.vuego:1:4: in expression '{{ items | double }}': double(): cannot convert argument 0 from []string to int", err.Error())
 }
 
 func TestVue_Funcs_StringToIntConversionVariations(t *testing.T) {
@@ -856,7 +856,7 @@ func TestVue_Funcs_StringToIntConversionVariations(t *testing.T) {
 		var buf bytes.Buffer
 		err := vue.Render(t.Context(), &buf, "test.vuego", data)
 		assert.Error(t, err)
-		assert.Equal(t, "in test.vuego: in expression '{{ convert(\"not-a-number\") }}': convert(): cannot convert argument 0 from string to int", err.Error())
+		assert.Equal(t, "test.vuego:1:4: in expression '{{ convert(\"not-a-number\") }}': convert(): cannot convert argument 0 from string to int", err.Error())
 	})
 
 	t.Run("invalid string to float", func(t *testing.T) {
@@ -877,7 +877,7 @@ func TestVue_Funcs_StringToIntConversionVariations(t *testing.T) {
 		var buf bytes.Buffer
 		err := vue.Render(t.Context(), &buf, "test.vuego", data)
 		assert.Error(t, err)
-		assert.Equal(t, "in test.vuego: in expression '{{ convert(\"not-a-float\") }}': convert(): cannot convert argument 0 from string to float64", err.Error())
+		assert.Equal(t, "test.vuego:1:4: in expression '{{ convert(\"not-a-float\") }}': convert(): cannot convert argument 0 from string to float64", err.Error())
 	})
 
 	t.Run("invalid string to bool", func(t *testing.T) {
@@ -898,7 +898,7 @@ func TestVue_Funcs_StringToIntConversionVariations(t *testing.T) {
 		var buf bytes.Buffer
 		err := vue.Render(t.Context(), &buf, "test.vuego", data)
 		assert.Error(t, err)
-		assert.Equal(t, "in test.vuego: in expression '{{ convert(\"maybe\") }}': convert(): cannot convert argument 0 from string to bool", err.Error())
+		assert.Equal(t, "test.vuego:1:4: in expression '{{ convert(\"maybe\") }}': convert(): cannot convert argument 0 from string to bool", err.Error())
 	})
 }
 
@@ -1266,7 +1266,7 @@ func TestVue_Funcs_LoadSVG(t *testing.T) {
 		var buf bytes.Buffer
 		err := vue.Render(t.Context(), &buf, "test.vuego", data)
 		assert.Error(t, err)
-		assert.Equal(t, "in test.vuego: in expression '{{ loadSVG(\"missing.svg\") }}': loadSVG(): failed to load SVG file 'missing.svg'", err.Error())
+		assert.Equal(t, "test.vuego:1:6: in expression '{{ loadSVG(\"missing.svg\") }}': loadSVG(): failed to load SVG file 'missing.svg'", err.Error())
 	})
 
 	t.Run("loadSVG error propagates through pipe", func(t *testing.T) {
@@ -1288,7 +1288,7 @@ func TestVue_Funcs_LoadSVG(t *testing.T) {
 		var buf bytes.Buffer
 		err := vue.Render(t.Context(), &buf, "test.vuego", data)
 		assert.Error(t, err)
-		assert.Equal(t, "in test.vuego: in expression '{{ path | loadSVG }}': loadSVG(): failed to load SVG file 'icons/check.svg'", err.Error())
+		assert.Equal(t, "test.vuego:1:6: in expression '{{ path | loadSVG }}': loadSVG(): failed to load SVG file 'icons/check.svg'", err.Error())
 	})
 
 	t.Run("loadSVG error context includes template name", func(t *testing.T) {
@@ -1308,7 +1308,7 @@ func TestVue_Funcs_LoadSVG(t *testing.T) {
 		var buf bytes.Buffer
 		err := vue.Render(t.Context(), &buf, "test.vuego", data)
 		assert.Error(t, err)
-		assert.Equal(t, "in test.vuego: in expression '{{ loadSVG(\"icons/nonexistent.svg\") }}': loadSVG(): failed to load SVG file 'icons/nonexistent.svg'", err.Error())
+		assert.Equal(t, "test.vuego:1:6: in expression '{{ loadSVG(\"icons/nonexistent.svg\") }}': loadSVG(): failed to load SVG file 'icons/nonexistent.svg'", err.Error())
 	})
 }
 
@@ -1447,8 +1447,8 @@ func TestVue_InterpolateUnescapedInScriptTag(t *testing.T) {
 		err := vue.Render(t.Context(), &buf, "test.vuego", data)
 		assert.NoError(t, err)
 		output := buf.String()
-		// Should NOT be HTML-escaped inside script tags
-		assert.Contains(t, output, `<div class="test">content</div>`)
+		// Should be JS-escaped, not HTML-escaped, inside script tags
+		assert.Contains(t, output, `const value = "\u003cdiv class=\"test\"\u003econtent\u003c/div\u003e";`)
 		assert.NotContains(t, output, `&lt;`)
 		assert.NotContains(t, output, `&gt;`)
 		assert.NotContains(t, output, `&#34;`)
@@ -1470,8 +1470,8 @@ func TestVue_InterpolateUnescapedInScriptTag(t *testing.T) {
 		err := vue.Render(t.Context(), &buf, "test.vuego", data)
 		assert.NoError(t, err)
 		output := buf.String()
-		// Should NOT be HTML-escaped inside script tags
-		assert.Contains(t, output, `test & check < > " '`)
+		// Should be JS-escaped, not HTML-escaped, inside script tags
+		assert.Contains(t, output, `const val = 'test \u0026 check \u003c \u003e \" \u0027';`)
 		assert.NotContains(t, output, `&amp;`)
 		assert.NotContains(t, output, `&lt;`)
 		assert.NotContains(t, output, `&gt;`)
@@ -0,0 +1,72 @@
+package vuego
+
+import (
+	"fmt"
+
+	"golang.org/x/net/html"
+)
+
+// GoComponent renders a component tag in Go, see RegisterGoComponent.
+//
+// It receives the evaluated attributes of the tag as props, the same way a
+// .vuego component receives them, and the slot content passed to the tag,
+// already evaluated in the scope of the calling template. The returned nodes
+// are written to the output as they are.
+type GoComponent func(ctx *VueContext, props map[string]any, slots *SlotScope) ([]*html.Node, error)
+
+// HTMLComponent adapts a function that returns markup to a GoComponent.
+// The markup is trusted and written to the output as is.
+func HTMLComponent(fn func(ctx *VueContext, props map[string]any, slots *SlotScope) ([]byte, error)) GoComponent {
+	return func(ctx *VueContext, props map[string]any, slots *SlotScope) ([]*html.Node, error) {
+		b, err := fn(ctx, props, slots)
+		if err != nil {
+			return nil, err
+		}
+		return []*html.Node{{Type: html.RawNode, Data: string(b)}}, nil
+	}
+}
+
+// RegisterGoComponent registers a component implemented in Go for a tag name,
+// e.g. "data-table" renders `<data-table :rows="rows">` with fn.
+func (v *Vue) RegisterGoComponent(tagName string, fn GoComponent) *Vue {
+	if v.goComponents == nil {
+		v.goComponents = make(map[string]GoComponent)
+	}
+	v.goComponents[tagName] = fn
+	return v
+}
+
+// WithGoComponent returns a LoadOption that registers a component implemented in Go.
+func WithGoComponent(tagName string, fn GoComponent) LoadOption {
+	return func(vue *Vue) {
+		vue.RegisterGoComponent(tagName, fn)
+	}
+}
+
+// evalGoComponent renders a tag registered with RegisterGoComponent.
+func (v *Vue) evalGoComponent(ctx VueContext, node *html.Node, fn GoComponent, depth int) ([]*html.Node, error) {
+	props, _, err := v.componentProps(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := NewSlotScope()
+	for name, content := range extractSlotContent(node).Slots {
+		var nodes []*html.Node
+		if content.TemplateNode != nil {
+			nodes, err = v.evaluateChildren(ctx, content.TemplateNode, depth+1)
+		} else {
+			nodes, err = v.evaluate(ctx, content.Nodes, depth+1)
+		}
+		if err != nil {
+			return nil, err
+		}
+		slots.SetSlot(name, &SlotContent{Nodes: nodes, Props: content.Props})
+	}
+
+	result, err := fn(&ctx, props, slots)
+	if err != nil {
+		return nil, fmt.Errorf("error in component %s (included from %s): %w", node.Data, ctx.FormatTemplateChain(), err)
+	}
+	return result, nil
+}
@@ -0,0 +1,115 @@
+package vuego_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func dataTable(ctx *vuego.VueContext, props map[string]any, slots *vuego.SlotScope) ([]*html.Node, error) {
+	table := &html.Node{Type: html.ElementNode, Data: "table"}
+	if caption := slots.GetSlot("caption"); caption != nil {
+		node := &html.Node{Type: html.ElementNode, Data: "caption"}
+		for _, n := range caption.Nodes {
+			node.AppendChild(n)
+		}
+		table.AppendChild(node)
+	}
+	rows, _ := props["rows"].([]any)
+	for _, row := range rows {
+		tr := &html.Node{Type: html.ElementNode, Data: "tr"}
+		td := &html.Node{Type: html.ElementNode, Data: "td"}
+		td.AppendChild(&html.Node{Type: html.TextNode, Data: fmt.Sprint(row)})
+		tr.AppendChild(td)
+		table.AppendChild(tr)
+	}
+	return []*html.Node{table}, nil
+}
+
+func TestGoComponent(t *testing.T) {
+	badge := vuego.HTMLComponent(func(ctx *vuego.VueContext, props map[string]any, slots *vuego.SlotScope) ([]byte, error) {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, `<span class="badge badge-%s">`, props["kind"])
+		if slot := slots.GetSlot("default"); slot != nil {
+			for _, n := range slot.Nodes {
+				if err := html.Render(&sb, n); err != nil {
+					return nil, err
+				}
+			}
+		}
+		sb.WriteString("</span>")
+		return []byte(sb.String()), nil
+	})
+
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "bound props and named slot",
+			template: `<data-table :rows="rows"><template #caption>{{ title }}</template></data-table>`,
+			data:     map[string]any{"rows": []any{"a", "<b>"}, "title": "Rows"},
+			want:     `<table><caption>Rows</caption><tr><td>a</td></tr><tr><td>&lt;b&gt;</td></tr></table>`,
+		},
+		{
+			name:     "json props",
+			template: `<data-table rows='["x"]'></data-table>`,
+			want:     `<table><tr><td>x</td></tr></table>`,
+		},
+		{
+			name:     "markup with default slot evaluated in caller scope",
+			template: `<p><ui-badge kind="info">{{ count }} new</ui-badge></p>`,
+			data:     map[string]any{"count": 3},
+			want:     `<p><span class="badge badge-info">3 new</span></p>`,
+		},
+		{
+			name:     "loops and conditions",
+			template: `<div><ui-badge v-for="k in kinds" :kind="k">{{ k }}</ui-badge><ui-badge v-if="false" kind="a"></ui-badge><ui-badge v-else kind="b">b</ui-badge></div>`,
+			data:     map[string]any{"kinds": []string{"x", "y"}},
+			want:     `<div><span class="badge badge-x">x</span><span class="badge badge-y">y</span><span class="badge badge-b">b</span></div>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"page.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+			tpl := vuego.NewFS(templateFS,
+				vuego.WithRenderer(vuego.NewFaithfulRenderer()),
+				vuego.WithGoComponent("data-table", dataTable),
+				vuego.WithGoComponent("ui-badge", badge),
+			)
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestGoComponent_Error(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte("<div>\n  <broken-widget></broken-widget>\n</div>")},
+	}
+	tpl := vuego.NewFS(templateFS, vuego.WithGoComponent("broken-widget", func(ctx *vuego.VueContext, props map[string]any, slots *vuego.SlotScope) ([]*html.Node, error) {
+		return nil, errors.New("widget failed")
+	}))
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Render(t.Context(), &buf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "page.vuego:2:3")
+	assert.Contains(t, err.Error(), "error in component broken-widget")
+	assert.Contains(t, err.Error(), "widget failed")
+}
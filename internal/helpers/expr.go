@@ -17,6 +17,20 @@ func IsFunctionCall(expr string) bool {
 	return false
 }
 
+// IsPath checks if an expression is a variable path like `item.name`, `$loop.index`
+// or `rows[0]`, as opposed to a literal like `0`, `'text'` or `true`, or an expression.
+func IsPath(expr string) bool {
+	expr = strings.TrimSpace(expr)
+	if expr == "" || (expr[0] != '$' && !IsIdentifierChar(rune(expr[0]), true)) {
+		return false
+	}
+	switch expr {
+	case "true", "false", "nil", "null":
+		return false
+	}
+	return !strings.ContainsAny(expr, " \t\r\n()+*%<>=!&|?:,")
+}
+
 // ContainsPipe checks if an expression contains a pipe operator.
 func ContainsPipe(expr string) bool {
 	for _, ch := range expr {
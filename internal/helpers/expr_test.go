@@ -165,3 +165,12 @@ func TestIsFunctionCall(t *testing.T) {
 		assert.True(t, helpers.IsFunctionCall("func123()"))
 	})
 }
+
+func TestIsPath(t *testing.T) {
+	for _, expr := range []string{"name", "item.name", "$loop.index", "rows[0]", "user-name"} {
+		assert.True(t, helpers.IsPath(expr), expr)
+	}
+	for _, expr := range []string{"", "0", "-1", "''", `"text"`, "true", "false", "nil", "[1, 2]", "a + b", "fn()"} {
+		assert.False(t, helpers.IsPath(expr), expr)
+	}
+}
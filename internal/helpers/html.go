@@ -0,0 +1,50 @@
+package helpers
+
+// voidElements are elements that have no end tag and no content.
+// The list follows the HTML5 fragment serialization algorithm.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "basefont": true, "bgsound": true, "br": true,
+	"col": true, "embed": true, "frame": true, "hr": true, "img": true,
+	"input": true, "keygen": true, "link": true, "meta": true, "param": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// preformattedElements are elements whose content is serialized verbatim,
+// without re-indenting or dropping whitespace.
+var preformattedElements = map[string]bool{
+	"pre": true, "textarea": true, "listing": true,
+	"script": true, "style": true, "xmp": true, "plaintext": true,
+}
+
+// booleanAttrs are the HTML boolean attributes. Their presence means true,
+// and they are removed rather than rendered with a false value.
+var booleanAttrs = map[string]bool{
+	"allowfullscreen": true, "async": true, "autofocus": true, "autoplay": true,
+	"checked": true, "controls": true, "default": true, "defer": true,
+	"disabled": true, "formnovalidate": true, "hidden": true, "inert": true,
+	"ismap": true, "itemscope": true, "loop": true, "multiple": true,
+	"muted": true, "nomodule": true, "novalidate": true, "open": true,
+	"playsinline": true, "readonly": true, "required": true, "reversed": true,
+	"selected": true,
+}
+
+// IsVoidElement returns true for elements like <br> and <img> that have no end tag.
+func IsVoidElement(tag string) bool {
+	return voidElements[tag]
+}
+
+// IsPreformattedElement returns true for elements like <pre> and <script>
+// whose whitespace is significant.
+func IsPreformattedElement(tag string) bool {
+	return preformattedElements[tag]
+}
+
+// IsRawTextElement returns true for <script> and <style>, whose content is not escaped.
+func IsRawTextElement(tag string) bool {
+	return tag == "script" || tag == "style"
+}
+
+// IsBooleanAttr returns true for HTML boolean attributes like disabled and checked.
+func IsBooleanAttr(key string) bool {
+	return booleanAttrs[key]
+}
@@ -0,0 +1,33 @@
+package helpers_test
+
+import (
+	"testing"
+
+	"github.com/titpetric/vuego/internal/helpers"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestIsVoidElement(t *testing.T) {
+	for _, tag := range []string{"br", "img", "input", "meta", "link", "hr", "wbr"} {
+		assert.True(t, helpers.IsVoidElement(tag), tag)
+	}
+	for _, tag := range []string{"div", "p", "template", "textarea", "BR"} {
+		assert.False(t, helpers.IsVoidElement(tag), tag)
+	}
+}
+
+func TestIsPreformattedElement(t *testing.T) {
+	for _, tag := range []string{"pre", "textarea", "script", "style"} {
+		assert.True(t, helpers.IsPreformattedElement(tag), tag)
+	}
+	assert.False(t, helpers.IsPreformattedElement("code"))
+}
+
+func TestIsBooleanAttr(t *testing.T) {
+	for _, key := range []string{"disabled", "checked", "selected", "required", "hidden"} {
+		assert.True(t, helpers.IsBooleanAttr(key), key)
+	}
+	for _, key := range []string{"value", "class", "aria-hidden", "draggable"} {
+		assert.False(t, helpers.IsBooleanAttr(key), key)
+	}
+}
@@ -98,6 +98,31 @@ func FilterAttrs(attrs []html.Attribute, excludeKey string) []html.Attribute {
 	return result
 }
 
+// Children returns the children of a node.
+func Children(n *html.Node) []*html.Node {
+	var result []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		result = append(result, c)
+	}
+	return result
+}
+
+// SetChildren replaces the children of a node, linking them as siblings.
+func SetChildren(n *html.Node, children []*html.Node) {
+	n.FirstChild, n.LastChild = nil, nil
+	var prev *html.Node
+	for _, c := range children {
+		c.Parent, c.PrevSibling, c.NextSibling = n, prev, nil
+		if prev == nil {
+			n.FirstChild = c
+		} else {
+			prev.NextSibling = c
+		}
+		prev = c
+	}
+	n.LastChild = prev
+}
+
 // CloneNode creates a shallow copy of a node without sharing children or siblings.
 // Attributes are shared (not copied) to avoid unnecessary allocations.
 func CloneNode(n *html.Node) *html.Node {
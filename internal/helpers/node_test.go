@@ -225,3 +225,22 @@ func TestDeepCloneNode(t *testing.T) {
 		assert.Equal(t, "third", c3.Data)
 	})
 }
+
+func TestSetChildren(t *testing.T) {
+	parent := &html.Node{Type: html.ElementNode, Data: "ul"}
+	a := &html.Node{Type: html.ElementNode, Data: "li"}
+	b := &html.Node{Type: html.ElementNode, Data: "li"}
+
+	helpers.SetChildren(parent, []*html.Node{a, b})
+	assert.Equal(t, a, parent.FirstChild)
+	assert.Equal(t, b, parent.LastChild)
+	assert.Equal(t, b, a.NextSibling)
+	assert.Equal(t, a, b.PrevSibling)
+	assert.Equal(t, parent, b.Parent)
+	assert.Equal(t, []*html.Node{a, b}, helpers.Children(parent))
+
+	helpers.SetChildren(parent, []*html.Node{b})
+	assert.Equal(t, b, parent.FirstChild)
+	assert.Nil(t, b.PrevSibling)
+	assert.Len(t, helpers.Children(parent), 1)
+}
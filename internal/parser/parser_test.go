@@ -31,3 +31,43 @@ func TestParseTemplateBytes(t *testing.T) {
 		assert.NotNil(t, nodes)
 	})
 }
+
+func TestParseTemplateBytesWithPositions(t *testing.T) {
+	src := []byte("<div class=\"a\">\n  <p v-if=\"show\"\n     :title=\"t\">Hi {{ name }}, {{ age | int }}</p>\n  <br/><span>ü{{ x }}</span>\n</div>")
+
+	nodes, positions, err := parser.ParseTemplateBytesWithPositions(src, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(nodes))
+
+	div := nodes[0]
+	assert.Equal(t, 1, len(div.Attr))
+	assert.Equal(t, parser.Pos{Line: 4, Column: 1}, positions[div].Pos)
+	assert.Equal(t, parser.Pos{Line: 4, Column: 6}, positions[div].Attrs["class"])
+
+	p := div.FirstChild.NextSibling
+	assert.Equal(t, "p", p.Data)
+	assert.Equal(t, 2, len(p.Attr))
+	assert.Equal(t, parser.Pos{Line: 5, Column: 3}, positions[p].Pos)
+	assert.Equal(t, parser.Pos{Line: 5, Column: 6}, positions[p].Attrs["v-if"])
+	assert.Equal(t, parser.Pos{Line: 6, Column: 6}, positions[p].Attrs[":title"])
+
+	text := positions[p.FirstChild]
+	assert.NotNil(t, text)
+	assert.Equal(t, parser.Pos{Line: 6, Column: 20}, text.Exprs["name"])
+	assert.Equal(t, parser.Pos{Line: 6, Column: 32}, text.Exprs["age | int"])
+
+	span := p.NextSibling.NextSibling.NextSibling
+	assert.Equal(t, "span", span.Data)
+	assert.Equal(t, parser.Pos{Line: 7, Column: 8}, positions[span].Pos)
+	assert.Equal(t, parser.Pos{Line: 7, Column: 15}, positions[span.FirstChild].Exprs["x"])
+}
+
+func TestParseTemplateBytesWithPositions_RawText(t *testing.T) {
+	src := []byte("<script>if (a < b) { x = \"<div>\" }</script><p>{{ y }}</p>")
+
+	nodes, positions, err := parser.ParseTemplateBytesWithPositions(src, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(nodes))
+	assert.Equal(t, `if (a < b) { x = "<div>" }`, nodes[0].FirstChild.Data)
+	assert.Equal(t, parser.Pos{Line: 1, Column: 44}, positions[nodes[1]].Pos)
+}
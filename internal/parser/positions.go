@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// posAttr is the attribute used to carry a token index through html.Parse.
+// It is added to every start tag before parsing and removed afterwards.
+const posAttr = "data-vuego-pos"
+
+// Pos is a 1-based line and column in template source.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+// NodePos is the source position of a parsed node.
+// For elements, Attrs holds the position of each attribute name.
+// For text nodes, Exprs holds the position of each {{ }} interpolation,
+// keyed by its trimmed expression.
+type NodePos struct {
+	Pos
+	Attrs map[string]Pos
+	Exprs map[string]Pos
+
+	offset int
+}
+
+// Positions maps parsed nodes to their source positions.
+// Nodes created by the HTML parser, like an implied <tbody>, have no position.
+type Positions map[*html.Node]*NodePos
+
+// ParseTemplateBytesWithPositions parses template bytes like ParseTemplateBytes and
+// also returns the source position of elements, attributes and interpolations.
+// The lineOffset is added to every line number, and accounts for content
+// stripped from the template before parsing, like front-matter.
+func ParseTemplateBytesWithPositions(templateBytes []byte, lineOffset int) ([]*html.Node, Positions, error) {
+	lines := newLineIndex(templateBytes, lineOffset)
+
+	annotated, tags := annotate(templateBytes, lines)
+
+	nodes, err := ParseTemplateBytes(annotated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	positions := make(Positions)
+	cursor := 0
+	var walk func(n *html.Node, parentOffset int)
+	walk = func(n *html.Node, parentOffset int) {
+		switch n.Type {
+		case html.ElementNode:
+			for i, attr := range n.Attr {
+				if attr.Key != posAttr {
+					continue
+				}
+				n.Attr = append(n.Attr[:i:i], n.Attr[i+1:]...)
+				if idx, err := strconv.Atoi(attr.Val); err == nil && idx < len(tags) {
+					positions[n] = tags[idx]
+					parentOffset = tags[idx].offset
+				}
+				break
+			}
+		case html.TextNode:
+			cursor = max(cursor, parentOffset)
+			if pos := textPositions(n.Data, templateBytes, &cursor, lines); pos != nil {
+				positions[n] = pos
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, parentOffset)
+		}
+	}
+	for _, node := range nodes {
+		walk(node, 0)
+	}
+
+	return nodes, positions, nil
+}
+
+// annotate tokenizes src and adds a posAttr holding the token index to each start tag.
+// The tokenizer handles raw text elements like <script> the same way the parser does,
+// so tags are only annotated where the parser will create elements from them.
+func annotate(src []byte, lines *lineIndex) ([]byte, []*NodePos) {
+	var (
+		out    bytes.Buffer
+		tags   []*NodePos
+		offset int
+	)
+	out.Grow(len(src) + len(src)/4)
+
+	z := html.NewTokenizer(bytes.NewReader(src))
+	for {
+		tt := z.Next()
+		raw := z.Raw()
+		if tt == html.ErrorToken {
+			out.Write(raw)
+			if z.Err() == io.EOF {
+				break
+			}
+			// Not reachable for a bytes.Reader, keep the rest verbatim
+			out.Write(src[offset+len(raw):])
+			break
+		}
+
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			nameEnd := tagNameEnd(raw)
+
+			pos := &NodePos{
+				Pos:    lines.pos(offset),
+				Attrs:  make(map[string]Pos),
+				offset: offset,
+			}
+			for key, attrOffset := range attrOffsets(raw, nameEnd) {
+				pos.Attrs[key] = lines.pos(offset + attrOffset)
+			}
+
+			out.Write(raw[:nameEnd])
+			out.WriteString(" " + posAttr + `="` + strconv.Itoa(len(tags)) + `"`)
+			out.Write(raw[nameEnd:])
+			tags = append(tags, pos)
+		} else {
+			out.Write(raw)
+		}
+		offset += len(raw)
+	}
+
+	return out.Bytes(), tags
+}
+
+// textPositions locates the interpolations of a text node in src, searching
+// forward from cursor. Text is matched in document order, so the cursor only moves forward.
+func textPositions(data string, src []byte, cursor *int, lines *lineIndex) *NodePos {
+	var result *NodePos
+	rest := data
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start + 2
+
+		interp := rest[start:end]
+		rest = rest[end:]
+
+		idx := bytes.Index(src[*cursor:], []byte(interp))
+		if idx < 0 {
+			continue
+		}
+		offset := *cursor + idx
+		*cursor = offset + len(interp)
+
+		pos := lines.pos(offset)
+		if result == nil {
+			result = &NodePos{Pos: pos, Exprs: make(map[string]Pos), offset: offset}
+		}
+		expr := strings.Trim(interp[2:len(interp)-2], " \t\n\r")
+		if _, ok := result.Exprs[expr]; !ok {
+			result.Exprs[expr] = pos
+		}
+	}
+	return result
+}
+
+// tagNameEnd returns the offset in a raw start tag where the tag name ends.
+func tagNameEnd(raw []byte) int {
+	i := 1
+	for i < len(raw) && !isSpace(raw[i]) && raw[i] != '/' && raw[i] != '>' {
+		i++
+	}
+	return i
+}
+
+// attrOffsets returns the offset of each attribute name in a raw start tag,
+// starting the scan at from. Keys are lowercased and the first occurrence wins,
+// matching how the tokenizer reports attributes.
+func attrOffsets(raw []byte, from int) map[string]int {
+	result := make(map[string]int)
+	i := from
+	for i < len(raw) {
+		for i < len(raw) && (isSpace(raw[i]) || raw[i] == '/') {
+			i++
+		}
+		if i >= len(raw) || raw[i] == '>' {
+			break
+		}
+
+		start := i
+		// A leading '=' is part of the attribute name
+		i++
+		for i < len(raw) && !isSpace(raw[i]) && raw[i] != '/' && raw[i] != '=' && raw[i] != '>' {
+			i++
+		}
+		key := strings.ToLower(string(raw[start:i]))
+		if _, ok := result[key]; !ok {
+			result[key] = start
+		}
+
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+		if i >= len(raw) || raw[i] != '=' {
+			continue
+		}
+		i++
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+		if i < len(raw) && (raw[i] == '"' || raw[i] == '\'') {
+			quote := raw[i]
+			i++
+			for i < len(raw) && raw[i] != quote {
+				i++
+			}
+			i++
+			continue
+		}
+		for i < len(raw) && !isSpace(raw[i]) && raw[i] != '>' {
+			i++
+		}
+	}
+	return result
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// lineIndex converts byte offsets into line and column positions.
+type lineIndex struct {
+	src    []byte
+	starts []int
+	offset int
+}
+
+func newLineIndex(src []byte, lineOffset int) *lineIndex {
+	starts := []int{0}
+	for i, c := range src {
+		if c == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &lineIndex{src: src, starts: starts, offset: lineOffset}
+}
+
+// pos returns the 1-based line and column of a byte offset. Columns count runes.
+func (l *lineIndex) pos(offset int) Pos {
+	line := sort.Search(len(l.starts), func(i int) bool {
+		return l.starts[i] > offset
+	}) - 1
+	return Pos{
+		Line:   line + 1 + l.offset,
+		Column: utf8.RuneCount(l.src[l.starts[line]:offset]) + 1,
+	}
+}
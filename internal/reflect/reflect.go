@@ -46,7 +46,7 @@ func resolveStruct(rv reflect.Value, fieldName string) (any, bool) {
 	rt := rv.Type()
 
 	// Try field name first
-	if f, ok := rt.FieldByName(fieldName); ok {
+	if f, ok := rt.FieldByName(fieldName); ok && f.IsExported() {
 		fv := rv.FieldByIndex(f.Index)
 		return fv.Interface(), true
 	}
@@ -55,7 +55,7 @@ func resolveStruct(rv reflect.Value, fieldName string) (any, bool) {
 	for i := range rt.NumField() {
 		f := rt.Field(i)
 		tag := f.Tag.Get("json")
-		if tag == "" {
+		if tag == "" || !f.IsExported() {
 			continue
 		}
 
@@ -112,24 +112,32 @@ func CanDescend(v any) bool {
 }
 
 // StructToMap converts a struct to a map using JSON tags for keys.
-// Nested structs are recursively converted to maps as well.
+// Field values keep their types, so nested structs keep their methods.
 func StructToMap(data any) map[string]any {
 	result := make(map[string]any)
+	PopulateStructFields(result, data)
+	return result
+}
+
+// PopulateStructFields adds exported struct fields to the map using JSON tags.
+// Field values keep their types; nested structs resolve JSON tags on access,
+// see ResolveValue and Member.
+func PopulateStructFields(m map[string]any, data any) {
 	if data == nil {
-		return result
+		return
 	}
 
 	rv := reflect.ValueOf(data)
 	// Dereference pointers
 	for rv.Kind() == reflect.Ptr {
 		if rv.IsNil() {
-			return result
+			return
 		}
 		rv = rv.Elem()
 	}
 
 	if rv.Kind() != reflect.Struct {
-		return result
+		return
 	}
 
 	rt := rv.Type()
@@ -150,68 +158,68 @@ func StructToMap(data any) map[string]any {
 			}
 		}
 
-		fv := rv.Field(i)
-		fieldValue := fv.Interface()
-
-		// Recursively convert nested structs
-		if fv.Kind() == reflect.Struct || (fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct) {
-			fieldValue = StructToMap(fieldValue)
-		}
-
-		result[tagName] = fieldValue
+		// Add the field itself (for path resolution like item.inStock)
+		m[tagName] = rv.Field(i).Interface()
 	}
-	return result
 }
 
-// PopulateStructFields adds exported struct fields to the map using JSON tags.
-// Nested structs are converted to maps to support path resolution like item.inStock.
-func PopulateStructFields(m map[string]any, data any) {
+// PopulateMethods adds the exported methods of data to the map, as bound
+// method values. Methods with pointer receivers are included for struct
+// values. Keys already in the map are kept.
+func PopulateMethods(m map[string]any, data any) {
 	if data == nil {
 		return
 	}
 
-	rv := reflect.ValueOf(data)
-	// Dereference pointers
-	for rv.Kind() == reflect.Ptr {
-		if rv.IsNil() {
-			return
+	rv := addressable(reflect.ValueOf(data))
+	rt := rv.Type()
+	for i := range rt.NumMethod() {
+		name := rt.Method(i).Name
+		if _, ok := m[name]; !ok {
+			m[name] = rv.Method(i).Interface()
 		}
-		rv = rv.Elem()
 	}
+}
 
-	if rv.Kind() != reflect.Struct {
-		return
+// Member returns the member name of v: a method, including methods with
+// pointer receivers, or a struct field by name or JSON tag. Maps and slices
+// are not handled. Returns (nil, false) if v has no such member.
+func Member(v any, name string) (any, bool) {
+	if v == nil {
+		return nil, false
 	}
 
-	rt := rv.Type()
-	for i := range rt.NumField() {
-		f := rt.Field(i)
-		// Only export fields
-		if !f.IsExported() {
-			continue
-		}
-
-		// Get the JSON tag name, default to field name if no tag
-		tagName := f.Name
-		if tag := f.Tag.Get("json"); tag != "" {
-			// Parse JSON tag, stripping options like "omitempty"
-			parts := strings.Split(tag, ",")
-			if parts[0] != "" {
-				tagName = parts[0]
-			}
+	rv := reflect.ValueOf(v)
+	if method := rv.MethodByName(name); method.IsValid() {
+		return method.Interface(), true
+	}
+	if rv.Kind() == reflect.Struct {
+		if _, ok := reflect.PointerTo(rv.Type()).MethodByName(name); ok {
+			return addressable(rv).MethodByName(name).Interface(), true
 		}
+	}
 
-		fv := rv.Field(i)
-		fieldValue := fv.Interface()
-
-		// Convert nested structs to maps so they can be accessed with JSON tag paths
-		if fv.Kind() == reflect.Struct || (fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct) {
-			fieldValue = StructToMap(fieldValue)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false
 		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, false
+	}
+	return resolveStruct(rv, name)
+}
 
-		// Add the field itself (for path resolution like item.inStock)
-		m[tagName] = fieldValue
+// addressable returns a pointer to a copy of a struct value, so methods with
+// pointer receivers can be called on it. Other values are returned as is.
+func addressable(rv reflect.Value) reflect.Value {
+	if rv.Kind() != reflect.Struct {
+		return rv
 	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	return ptr
 }
 
 // IsSlice reports whether v is a slice or array.
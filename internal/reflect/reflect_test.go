@@ -1,6 +1,7 @@
 package reflect_test
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/titpetric/vuego"
@@ -411,6 +412,61 @@ func TestResolveValue_WithVueStack(t *testing.T) {
 	assert.Equal(t, 100, optsVal.PoolSize)
 }
 
+// TestMember resolves methods, including pointer receivers, and struct fields.
+func TestMember(t *testing.T) {
+	opts := Options{ServerAddr: "localhost:5432", PoolSize: 10}
+	user := User{FirstName: "Ada", Profile: &Profile{Bio: "math"}}
+
+	tests := []struct {
+		name  string
+		value any
+		field string
+		want  any
+		ok    bool
+	}{
+		{name: "field by name", value: opts, field: "ServerAddr", want: "localhost:5432", ok: true},
+		{name: "field by JSON tag", value: user, field: "first_name", want: "Ada", ok: true},
+		{name: "field through pointer", value: &user, field: "FirstName", want: "Ada", ok: true},
+		{name: "missing member", value: opts, field: "Missing", ok: false},
+		{name: "map is not handled", value: map[string]any{"a": 1}, field: "a", ok: false},
+		{name: "nil value", value: nil, field: "a", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := reflect.Member(tt.value, tt.field)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("method with value receiver", func(t *testing.T) {
+		got, ok := reflect.Member(opts, "Addr")
+		assert.True(t, ok)
+		assert.Equal(t, "localhost:5432/10", got.(func() string)())
+	})
+
+	t.Run("method with pointer receiver on a value", func(t *testing.T) {
+		got, ok := reflect.Member(opts, "Pooled")
+		assert.True(t, ok)
+		assert.True(t, got.(func() bool)())
+	})
+}
+
+// TestStructToMap_KeepsTypes keeps nested structs typed, so their methods are available.
+func TestStructToMap_KeepsTypes(t *testing.T) {
+	config := Config{AppName: "MyApp", Database: &Database{Options: &Options{PoolSize: 5}}}
+
+	m := reflect.StructToMap(config)
+	assert.Equal(t, "MyApp", m["AppName"])
+	assert.Equal(t, config.Database, m["Database"])
+
+	methods := map[string]any{}
+	reflect.PopulateMethods(methods, Options{PoolSize: 5})
+	assert.Equal(t, 2, len(methods))
+	assert.True(t, methods["Pooled"].(func() bool)())
+}
+
 // Test data structures with multiple levels of nesting
 
 type Config struct {
@@ -429,6 +485,14 @@ type Options struct {
 	PoolSize   int
 }
 
+func (o Options) Addr() string {
+	return o.ServerAddr + "/" + strconv.Itoa(o.PoolSize)
+}
+
+func (o *Options) Pooled() bool {
+	return o.PoolSize > 0
+}
+
 type User struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
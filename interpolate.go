@@ -1,12 +1,11 @@
 package vuego
 
 import (
-	"fmt"
-	"html"
-	"io"
 	"strings"
 	"sync"
 
+	"golang.org/x/net/html"
+
 	"github.com/titpetric/vuego/internal/helpers"
 )
 
@@ -22,131 +21,125 @@ func containsInterpolation(input string) bool {
 	return open == close && open > 0
 }
 
-// interpolateToWriter writes interpolated values to w, escaping for HTML safety.
-// This is the core implementation that does not allocate a string result.
-// For script and style tags, values are not HTML-escaped.
-func (v *Vue) interpolateToWriter(ctx VueContext, w io.Writer, input string) error {
-	if !strings.Contains(input, "{{") {
-		_, err := io.WriteString(w, input)
-		return err
-	}
-
-	last := 0
-
-	for {
-		// Find the next {{ occurrence
-		start := strings.Index(input[last:], "{{")
-		if start < 0 {
-			break
-		}
-		start += last
-
-		// Find the closing }}
-		endPos := strings.Index(input[start+2:], "}}")
-		if endPos < 0 {
-			break
-		}
-		endPos += start + 2 // Position of first }
-		endPos += 2         // Skip past }}
-
-		// Write the text before the interpolation
-		if _, err := io.WriteString(w, input[last:start]); err != nil {
-			return err
+// interpolateText evaluates the interpolations of a text node. Inside <script>
+// and <style>, values are escaped as JS and CSS. Elsewhere, text is left for
+// the renderer to HTML-escape, and HTML values are split out into raw nodes
+// so they are written as is.
+func (v *Vue) interpolateText(ctx VueContext, node *html.Node) ([]*html.Node, error) {
+	context := textContext(ctx.CurrentTag())
+	if context != contextHTML || !containsInterpolation(node.Data) {
+		text, err := v.interpolateContext(ctx, node.Data, context)
+		if err != nil {
+			return nil, err
 		}
+		newNode := helpers.CloneNode(node)
+		newNode.Data = text
+		return []*html.Node{newNode}, nil
+	}
 
-		// Extract the expression between {{ and }}, trim whitespace
-		exprStart := start + 2
-		exprEnd := endPos - 2
+	buf := bufferPool.Get().(*strings.Builder)
+	defer func() {
+		buf.Reset()
+		bufferPool.Put(buf)
+	}()
 
-		// Skip leading whitespace
-		for exprStart < exprEnd && (input[exprStart] == ' ' || input[exprStart] == '\t' || input[exprStart] == '\n' || input[exprStart] == '\r') {
-			exprStart++
+	var result []*html.Node
+	flush := func() {
+		if buf.Len() > 0 {
+			newNode := helpers.CloneNode(node)
+			newNode.Data = buf.String()
+			result = append(result, newNode)
+			buf.Reset()
 		}
+	}
 
-		// Skip trailing whitespace
-		for exprEnd > exprStart && (input[exprEnd-1] == ' ' || input[exprEnd-1] == '\t' || input[exprEnd-1] == '\n' || input[exprEnd-1] == '\r') {
-			exprEnd--
+	for _, segment := range v.segments(node.Data) {
+		buf.WriteString(segment.text)
+		if !segment.interp {
+			continue
 		}
 
-		expr := input[exprStart:exprEnd]
-
-		var val any
-		var err error
-
-		// Try unified pipe/expr evaluation (handles both filters and expressions)
-		if strings.Contains(expr, "|") || helpers.IsFunctionCall(expr) || helpers.IsComplexExpr(expr) {
-			pipe := parsePipeExpr(expr)
-			val, err = v.evalPipe(ctx, pipe)
-			if err != nil {
-				return fmt.Errorf("in expression '{{ %s }}': %w", expr, err)
-			}
-		} else {
-			// Simple variable reference
-			var ok bool
-			val, ok = ctx.stack.Resolve(expr)
-			if !ok {
-				val = nil
-			}
+		val, err := v.segmentValue(ctx, segment)
+		if err != nil {
+			return nil, err
 		}
-
-		if val != nil {
-			// Escape value for HTML output (unless in a script/style tag)
-			valStr := fmt.Sprint(val)
-			parentTag := ctx.CurrentTag()
-			// Skip escaping inside script and style tags, since they contain code/CSS, not HTML
-			if parentTag == "script" || parentTag == "style" {
-				if _, err := io.WriteString(w, valStr); err != nil {
-					return err
-				}
-			} else {
-				// Skip escaping if the string doesn't contain special characters
-				// (avoids allocation in html.EscapeString for most cases)
-				if !helpers.NeedsHTMLEscape(valStr) {
-					if _, err := io.WriteString(w, valStr); err != nil {
-						return err
-					}
-				} else {
-					if _, err := io.WriteString(w, html.EscapeString(valStr)); err != nil {
-						return err
-					}
-				}
-			}
+		switch val := val.(type) {
+		case nil:
+		case HTML:
+			flush()
+			result = append(result, &html.Node{Type: html.RawNode, Data: string(val)})
+		case string:
+			buf.WriteString(val)
+		default:
+			buf.WriteString(formatValue(val))
 		}
-
-		last = endPos
 	}
+	flush()
 
-	// Write remaining text
-	if _, err := io.WriteString(w, input[last:]); err != nil {
-		return err
-	}
+	return result, nil
+}
 
-	return nil
+// interpolateAttr interpolates the value of the named attribute. Values are
+// escaped for URL, event handler and style attributes, and left for the
+// renderer to HTML-escape otherwise.
+func (v *Vue) interpolateAttr(ctx VueContext, name, input string) (string, error) {
+	return v.interpolateContext(ctx, input, attrContext(name))
 }
 
-// interpolate escapes interpolated values for HTML safety.
+// interpolateContext interpolates input, escaping values for context.
 // Uses a buffer pool to minimize allocations.
-// For script and style tags, values are not HTML-escaped.
-func (v *Vue) interpolate(ctx VueContext, input string) (string, error) {
+func (v *Vue) interpolateContext(ctx VueContext, input string, context escapeContext) (string, error) {
+	// Early return for no-interpolation case
+	if !containsInterpolation(input) {
+		return input, nil
+	}
+
 	buf := bufferPool.Get().(*strings.Builder)
 	defer func() {
 		buf.Reset()
 		bufferPool.Put(buf)
 	}()
 
-	// Early return for no-interpolation case
-	if !containsInterpolation(input) {
-		return input, nil
-	}
-
 	// Pre-allocate builder capacity to reduce grow operations
-	// Estimate output will be ~120% of input (for escaped HTML entities)
+	// Estimate output will be ~120% of input (for escaped values)
 	estimatedLen := len(input) + len(input)/5
 	buf.Grow(estimatedLen)
 
-	if err := v.interpolateToWriter(ctx, buf, input); err != nil {
-		return "", err
+	e := newEscaper(context)
+	for _, segment := range v.segments(input) {
+		buf.WriteString(segment.text)
+		e.literal(segment.text)
+		if !segment.interp {
+			continue
+		}
+
+		val, err := v.segmentValue(ctx, segment)
+		if err != nil {
+			return "", err
+		}
+		if val != nil {
+			buf.WriteString(e.value(val))
+		}
 	}
 
 	return buf.String(), nil
 }
+
+// segmentValue evaluates an interpolated segment.
+func (v *Vue) segmentValue(ctx VueContext, segment textSegment) (any, error) {
+	if segment.pipe != nil {
+		// Unified pipe/expr evaluation (handles both filters and expressions)
+		val, err := v.evalPipe(ctx, *segment.pipe)
+		if err != nil {
+			return nil, &exprError{expr: segment.expr, err: err}
+		}
+		return val, nil
+	}
+
+	// Simple variable reference
+	val, ok := ctx.stack.Resolve(segment.expr)
+	if !ok {
+		return nil, v.checkPath(ctx, segment.expr)
+	}
+	return val, nil
+}
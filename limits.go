@@ -0,0 +1,185 @@
+package vuego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// Limits bounds the resources a single render may use, for templates from
+// untrusted sources like user-editable themes. A zero field is not limited.
+type Limits struct {
+	// MaxIncludeDepth is the maximum depth of nested includes and components.
+	MaxIncludeDepth int
+	// AllowRecursion allows a template to include itself, directly or through
+	// other templates. Otherwise an include cycle fails with ErrIncludeCycle.
+	// Recursion is bounded by MaxIncludeDepth.
+	AllowRecursion bool
+	// MaxIterations is the maximum number of v-for iterations in total.
+	MaxIterations int
+	// MaxNodes is the maximum number of nodes evaluated, which bounds the size
+	// of the output DOM.
+	MaxNodes int
+	// MaxOutputBytes is the maximum size of the rendered output.
+	MaxOutputBytes int64
+	// MaxExprTime is the maximum time a single expression may take to evaluate.
+	// Functions taking a context.Context are passed a context that is cancelled
+	// after MaxExprTime and should return once it is done. Other functions can't
+	// be interrupted, so the limit is checked when the expression returns.
+	MaxExprTime time.Duration
+}
+
+// Errors wrapped by a *LimitError, one for each of the Limits.
+var (
+	ErrIncludeDepth   = errors.New("include depth limit exceeded")
+	ErrIncludeCycle   = errors.New("include cycle")
+	ErrIterationLimit = errors.New("iteration limit exceeded")
+	ErrNodeLimit      = errors.New("node limit exceeded")
+	ErrOutputLimit    = errors.New("output size limit exceeded")
+	ErrExprTimeLimit  = errors.New("expression time limit exceeded")
+)
+
+// LimitError is returned when a render exceeds one of its Limits. It wraps
+// the error of the limit, like ErrIterationLimit, for use with errors.Is.
+type LimitError struct {
+	// Err is the error of the exceeded limit.
+	Err error
+	// Chain is the template inclusion chain, as formatted by VueContext.FormatTemplateChain.
+	Chain string
+	// Detail describes the exceeded limit.
+	Detail string
+}
+
+// Error returns the template chain, the exceeded limit and its detail.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("in %s: %v: %s", e.Chain, e.Err, e.Detail)
+}
+
+// Unwrap returns the error of the exceeded limit.
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// WithLimits returns a LoadOption that sets the resource limits of a render.
+// Setting limits also enables include cycle detection, see Limits.AllowRecursion.
+func WithLimits(limits Limits) LoadOption {
+	return func(vue *Vue) {
+		vue.limits = &limits
+	}
+}
+
+// renderBudget counts the resources used by a render, see Limits.
+type renderBudget struct {
+	iterations int
+	nodes      int
+}
+
+func newLimitError(ctx VueContext, err error, format string, args ...any) *LimitError {
+	return &LimitError{
+		Err:    err,
+		Chain:  ctx.FormatTemplateChain(),
+		Detail: fmt.Sprintf(format, args...),
+	}
+}
+
+// isFatalError reports whether err must fail the render, even where evaluation
+// falls back to other strategies: strict mode errors and exceeded limits.
+func isFatalError(err error) bool {
+	if isStrictError(err) {
+		return true
+	}
+	var limitErr *LimitError
+	return errors.As(err, &limitErr)
+}
+
+// checkInclude checks the include depth and include cycles before name is
+// included from the templates in the chain of ctx.
+func (v *Vue) checkInclude(ctx VueContext, name string) error {
+	if v.limits == nil {
+		return nil
+	}
+	if limit := v.limits.MaxIncludeDepth; limit > 0 && len(ctx.TemplateStack) > limit {
+		return newLimitError(ctx, ErrIncludeDepth, "including %s exceeds the maximum depth of %d", name, limit)
+	}
+	if !v.limits.AllowRecursion && slices.Contains(ctx.TemplateStack, name) {
+		return newLimitError(ctx, ErrIncludeCycle, "%s -> %s", ctx.FormatTemplateChain(), name)
+	}
+	return nil
+}
+
+// countIteration counts a v-for iteration against Limits.MaxIterations.
+func (v *Vue) countIteration(ctx VueContext) error {
+	if v.limits == nil || v.limits.MaxIterations <= 0 || ctx.budget == nil {
+		return nil
+	}
+	ctx.budget.iterations++
+	if ctx.budget.iterations > v.limits.MaxIterations {
+		return newLimitError(ctx, ErrIterationLimit, "more than %d iterations", v.limits.MaxIterations)
+	}
+	return nil
+}
+
+// countNode counts an evaluated node against Limits.MaxNodes.
+func (v *Vue) countNode(ctx VueContext) error {
+	if v.limits == nil || v.limits.MaxNodes <= 0 || ctx.budget == nil {
+		return nil
+	}
+	ctx.budget.nodes++
+	if ctx.budget.nodes > v.limits.MaxNodes {
+		return newLimitError(ctx, ErrNodeLimit, "more than %d nodes", v.limits.MaxNodes)
+	}
+	return nil
+}
+
+// withExprDeadline returns a copy of ctx whose context.Context is cancelled
+// after Limits.MaxExprTime, for the functions called by a single expression.
+// The returned cancel func must be called once the expression returns.
+func (v *Vue) withExprDeadline(ctx VueContext) (VueContext, context.CancelFunc) {
+	parent := ctx.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	exprCtx, cancel := context.WithTimeout(parent, v.limits.MaxExprTime)
+	ctx.ctx = exprCtx
+	return ctx, cancel
+}
+
+// timeExpr returns an error if an expression that started at start took longer
+// than Limits.MaxExprTime.
+func (v *Vue) timeExpr(ctx VueContext, expression string, start time.Time) error {
+	if v.limits == nil || v.limits.MaxExprTime <= 0 {
+		return nil
+	}
+	if elapsed := time.Since(start); elapsed > v.limits.MaxExprTime {
+		return newLimitError(ctx, ErrExprTimeLimit, "expression '%s' took %v, more than %v", expression, elapsed.Round(time.Millisecond), v.limits.MaxExprTime)
+	}
+	return nil
+}
+
+// limitOutput returns a writer that fails with ErrOutputLimit once more than
+// Limits.MaxOutputBytes are written to w.
+func (v *Vue) limitOutput(ctx VueContext, w io.Writer) io.Writer {
+	if v.limits == nil || v.limits.MaxOutputBytes <= 0 {
+		return w
+	}
+	return &limitWriter{ctx: ctx, w: w, max: v.limits.MaxOutputBytes}
+}
+
+type limitWriter struct {
+	ctx VueContext
+	w   io.Writer
+	max int64
+	n   int64
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if l.n+int64(len(p)) > l.max {
+		return 0, newLimitError(l.ctx, ErrOutputLimit, "more than %d bytes", l.max)
+	}
+	n, err := l.w.Write(p)
+	l.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,140 @@
+package vuego_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestLimits(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"a.vuego":     &fstest.MapFile{Data: []byte(`<div><template include="b.vuego"></template></div>`)},
+		"b.vuego":     &fstest.MapFile{Data: []byte(`<p><template include="a.vuego"></template></p>`)},
+		"tree.vuego":  &fstest.MapFile{Data: []byte(`<ul><li>{{ node.name }}<template v-if="node.child"><template include="tree.vuego" :node="node.child"></template></template></li></ul>`)},
+		"list.vuego":  &fstest.MapFile{Data: []byte(`<ul><li v-for="row in rows"><span v-for="col in cols">{{ col }}</span></li></ul>`)},
+		"slow.vuego":  &fstest.MapFile{Data: []byte(`<p>{{ slow() }}</p>`)},
+		"plain.vuego": &fstest.MapFile{Data: []byte(`<p>{{ text }}</p>`)},
+		"wait.vuego":  &fstest.MapFile{Data: []byte(`<p>{{ wait() }}</p><p>{{ "x" + wait() }}</p>`)},
+		"class.vuego": &fstest.MapFile{Data: []byte(`<p :class="{x: lag()}">a</p>`)},
+		"style.vuego": &fstest.MapFile{Data: []byte(`<p :style="{color: block()}">a</p>`)},
+	}
+
+	tree := map[string]any{"name": "1", "child": map[string]any{"name": "2", "child": map[string]any{"name": "3"}}}
+	data := map[string]any{
+		"node": tree,
+		"rows": make([]int, 10),
+		"cols": make([]int, 10),
+		"text": "Hello, world",
+		// Object bindings call functions from the data
+		"lag": func() bool {
+			time.Sleep(20 * time.Millisecond)
+			return true
+		},
+		"block": func(ctx context.Context) string {
+			<-ctx.Done()
+			return "red"
+		},
+	}
+
+	render := func(limits vuego.Limits, filename string) (string, error) {
+		tpl := vuego.NewFS(templateFS,
+			vuego.WithLimits(limits),
+			vuego.WithRenderer(vuego.NewMinifyRenderer()),
+			vuego.WithFuncs(vuego.FuncMap{"slow": func() string {
+				time.Sleep(20 * time.Millisecond)
+				return "slow"
+			}, "wait": func(ctx context.Context) string {
+				<-ctx.Done()
+				return "done"
+			}}),
+		)
+		var buf bytes.Buffer
+		err := tpl.Load(filename).Fill(data).Render(t.Context(), &buf)
+		return buf.String(), err
+	}
+
+	t.Run("include cycle", func(t *testing.T) {
+		_, err := render(vuego.Limits{}, "a.vuego")
+		assert.ErrorIs(t, err, vuego.ErrIncludeCycle)
+		assert.Contains(t, err.Error(), "a.vuego -> b.vuego -> a.vuego")
+	})
+
+	t.Run("recursion", func(t *testing.T) {
+		out, err := render(vuego.Limits{AllowRecursion: true, MaxIncludeDepth: 3}, "tree.vuego")
+		assert.NoError(t, err)
+		assert.Equal(t, `<ul><li>1<ul><li>2<ul><li>3</li></ul></li></ul></li></ul>`, out)
+
+		_, err = render(vuego.Limits{AllowRecursion: true, MaxIncludeDepth: 1}, "tree.vuego")
+		assert.ErrorIs(t, err, vuego.ErrIncludeDepth)
+
+		_, err = render(vuego.Limits{}, "tree.vuego")
+		assert.ErrorIs(t, err, vuego.ErrIncludeCycle)
+	})
+
+	t.Run("iterations", func(t *testing.T) {
+		_, err := render(vuego.Limits{MaxIterations: 110}, "list.vuego")
+		assert.NoError(t, err)
+
+		_, err = render(vuego.Limits{MaxIterations: 109}, "list.vuego")
+		assert.ErrorIs(t, err, vuego.ErrIterationLimit)
+	})
+
+	t.Run("nodes", func(t *testing.T) {
+		_, err := render(vuego.Limits{MaxNodes: 50}, "list.vuego")
+		assert.ErrorIs(t, err, vuego.ErrNodeLimit)
+	})
+
+	t.Run("output size", func(t *testing.T) {
+		out, err := render(vuego.Limits{MaxOutputBytes: 19}, "plain.vuego")
+		assert.NoError(t, err)
+		assert.Equal(t, `<p>Hello, world</p>`, out)
+
+		out, err = render(vuego.Limits{MaxOutputBytes: 18}, "plain.vuego")
+		assert.ErrorIs(t, err, vuego.ErrOutputLimit)
+		assert.Equal(t, "", out)
+	})
+
+	t.Run("expression time", func(t *testing.T) {
+		_, err := render(vuego.Limits{MaxExprTime: 5 * time.Millisecond}, "slow.vuego")
+		assert.ErrorIs(t, err, vuego.ErrExprTimeLimit)
+		assert.Contains(t, err.Error(), "expression 'slow()'")
+
+		out, err := render(vuego.Limits{MaxExprTime: time.Second}, "slow.vuego")
+		assert.NoError(t, err)
+		assert.Equal(t, `<p>slow</p>`, out)
+	})
+
+	t.Run("expression deadline", func(t *testing.T) {
+		start := time.Now()
+		_, err := render(vuego.Limits{MaxExprTime: 5 * time.Millisecond}, "wait.vuego")
+		assert.ErrorIs(t, err, vuego.ErrExprTimeLimit)
+		assert.True(t, time.Since(start) < time.Second)
+	})
+
+	t.Run("expression time in object bindings", func(t *testing.T) {
+		_, err := render(vuego.Limits{MaxExprTime: 5 * time.Millisecond}, "class.vuego")
+		assert.ErrorIs(t, err, vuego.ErrExprTimeLimit)
+		assert.Contains(t, err.Error(), "expression 'lag()'")
+
+		start := time.Now()
+		_, err = render(vuego.Limits{MaxExprTime: 5 * time.Millisecond}, "style.vuego")
+		assert.ErrorIs(t, err, vuego.ErrExprTimeLimit)
+		assert.True(t, time.Since(start) < time.Second)
+
+		out, err := render(vuego.Limits{MaxExprTime: time.Second}, "class.vuego")
+		assert.NoError(t, err)
+		assert.Equal(t, `<p class=x>a</p>`, out)
+	})
+
+	t.Run("output size with the default renderer", func(t *testing.T) {
+		tpl := vuego.NewFS(templateFS, vuego.WithLimits(vuego.Limits{MaxOutputBytes: 10}))
+		var buf bytes.Buffer
+		err := tpl.Load("plain.vuego").Fill(data).Render(t.Context(), &buf)
+		assert.ErrorIs(t, err, vuego.ErrOutputLimit)
+	})
+}
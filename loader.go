@@ -72,7 +72,7 @@ func extractFrontMatter(content []byte) (map[string]any, []byte, error) {
 // LoadFragment parses a template fragment; if the file is a full document, it falls back to Load.
 // Front-matter is extracted and discarded; use loadFragment to access it.
 func (l *Loader) LoadFragment(filename string) ([]*html.Node, error) {
-	_, templateBytes, err := l.loadFragment(filename)
+	_, templateBytes, _, err := l.loadFragment(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -80,20 +80,22 @@ func (l *Loader) LoadFragment(filename string) ([]*html.Node, error) {
 }
 
 // loadFragment loads a template file and extracts front-matter, returning the raw template bytes.
-func (l *Loader) loadFragment(filename string) (map[string]any, []byte, error) {
+// The returned line offset is the number of lines taken by front-matter, which precede the template bytes.
+func (l *Loader) loadFragment(filename string) (map[string]any, []byte, int, error) {
 	if l.FS == nil {
-		return nil, nil, fmt.Errorf("error reading %s: no filesystem configured", filename)
+		return nil, nil, 0, fmt.Errorf("error reading %s: no filesystem configured", filename)
 	}
 	template, err := fs.ReadFile(l.FS, filename)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading %s: %w", filename, err)
+		return nil, nil, 0, fmt.Errorf("error reading %s: %w", filename, err)
 	}
 
 	// Extract front-matter
 	frontMatter, templateContent, err := extractFrontMatter(template)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
 
-	return frontMatter, templateContent, nil
+	lineOffset := bytes.Count(template[:len(template)-len(templateContent)], []byte("\n"))
+	return frontMatter, templateContent, lineOffset, nil
 }
@@ -382,10 +382,14 @@ func (m *Markdown) collectCells(node ast.Node, src []byte) []map[string]any {
 	var cells []map[string]any
 	for cell := node.FirstChild(); cell != nil; cell = cell.NextSibling() {
 		if tc, ok := cell.(*east.TableCell); ok {
-			cells = append(cells, map[string]any{
-				"align":   alignString(tc.Alignment),
+			data := map[string]any{
 				"content": m.inlineContent(tc, src),
-			})
+			}
+			// Unaligned cells leave align unset, an empty :align would render align=""
+			if align := alignString(tc.Alignment); align != "" {
+				data["align"] = align
+			}
+			cells = append(cells, data)
 		}
 	}
 	return cells
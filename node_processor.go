@@ -47,7 +47,7 @@ func (v *Vue) GetComponentFile(tagName string) (string, bool) {
 }
 
 // resolveComponentTags replaces component shorthand tags with template include directives.
-// This is called before other node processors to transform component tags.
+// This is called when a template is compiled, before any node processors run.
 func (v *Vue) resolveComponentTags(nodes []*html.Node) error {
 	for _, node := range nodes {
 		if err := v.processComponentNode(node); err != nil {
@@ -93,15 +93,9 @@ func (v *Vue) replaceWithInclude(node *html.Node, filename string) error {
 	return nil
 }
 
-// preProcessNodes applies all registered node processors to the rendered nodes.
-// Component tag resolution happens first, before other processors.
+// preProcessNodes applies all registered node processors to the compiled nodes.
+// Component tags have already been resolved by compile.
 func (v *Vue) preProcessNodes(ctx VueContext, nodes []*html.Node) error {
-	// Resolve component shorthand tags first
-	if err := v.resolveComponentTags(nodes); err != nil {
-		return err
-	}
-
-	// Then apply registered node processors
 	for _, processor := range ctx.Processors {
 		if err := processor.PreProcess(nodes); err != nil {
 			return err
@@ -0,0 +1,239 @@
+package vuego
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// propSpec is a declared component prop, see componentProps.
+type propSpec struct {
+	name     string
+	typ      string
+	required bool
+	def      any
+	hasDef   bool
+	enum     []any
+}
+
+// propTypes are the supported prop types and their aliases.
+var propTypes = map[string]string{
+	"":        "any",
+	"any":     "any",
+	"string":  "string",
+	"int":     "int",
+	"integer": "int",
+	"float":   "float",
+	"number":  "float",
+	"bool":    "bool",
+	"boolean": "bool",
+	"array":   "array",
+	"object":  "object",
+}
+
+// propDeclaration returns the props declared by a component, either with a
+// `props` key in the front matter or a `:props` attribute on the root template.
+// It returns nil if the component doesn't declare props.
+//
+// A declaration maps prop names to a type name, or to a spec with the keys
+// type, required, default and enum. A list of names declares untyped props.
+func (v *Vue) propDeclaration(frontMatter map[string]any, compDom []*html.Node) ([]*propSpec, error) {
+	decl, ok := frontMatter["props"]
+	if !ok {
+		if len(compDom) == 0 || compDom[0].Type != html.ElementNode || compDom[0].Data != "template" {
+			return nil, nil
+		}
+		for _, attr := range compDom[0].Attr {
+			if attr.Key == ":props" || attr.Key == "v-bind:props" {
+				val, err := v.exprEval.Eval(strings.TrimSpace(attr.Val), map[string]any{})
+				if err != nil {
+					return nil, fmt.Errorf("invalid props declaration: %w", err)
+				}
+				decl, ok = val, true
+				break
+			}
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	var specs []*propSpec
+	rv := reflect.ValueOf(decl)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			specs = append(specs, &propSpec{name: fmt.Sprint(rv.Index(i).Interface()), typ: "any"})
+		}
+		return specs, nil
+	case reflect.Map:
+	default:
+		return nil, fmt.Errorf("invalid props declaration: expected a map or a list, got %T", decl)
+	}
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		spec, err := newPropSpec(fmt.Sprint(iter.Key().Interface()), iter.Value().Interface())
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].name < specs[j].name
+	})
+	return specs, nil
+}
+
+// newPropSpec parses the declaration of a single prop.
+func newPropSpec(name string, decl any) (*propSpec, error) {
+	spec := &propSpec{name: name}
+
+	var typ any
+	switch decl := decl.(type) {
+	case nil:
+	case string:
+		typ = decl
+	case map[string]any:
+		typ = decl["type"]
+		if required, ok := decl["required"].(bool); ok {
+			spec.required = required
+		}
+		spec.def, spec.hasDef = decl["default"]
+		if enum, ok := decl["enum"]; ok {
+			rv := reflect.ValueOf(enum)
+			if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+				return nil, fmt.Errorf("invalid declaration of prop '%s': enum must be a list", name)
+			}
+			for i := range rv.Len() {
+				spec.enum = append(spec.enum, rv.Index(i).Interface())
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid declaration of prop '%s': %T", name, decl)
+	}
+
+	typeName, _ := typ.(string)
+	if typ != nil && typeName == "" {
+		return nil, fmt.Errorf("invalid declaration of prop '%s': type must be a string", name)
+	}
+	var ok bool
+	if spec.typ, ok = propTypes[strings.ToLower(typeName)]; !ok {
+		return nil, fmt.Errorf("invalid declaration of prop '%s': unknown type '%s'", name, typeName)
+	}
+
+	if spec.hasDef && spec.def != nil {
+		def, err := spec.coerce(spec.def)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default of prop '%s': %w", name, err)
+		}
+		spec.def = def
+	}
+	return spec, nil
+}
+
+// applyProps coerces and validates the declared props in vars, and sets defaults
+// for props that are not passed.
+func applyProps(specs []*propSpec, vars map[string]any) error {
+	for _, spec := range specs {
+		val, ok := vars[spec.name]
+		if !ok {
+			if spec.required {
+				return fmt.Errorf("required prop '%s' not provided", spec.name)
+			}
+			if spec.hasDef {
+				vars[spec.name] = spec.def
+			}
+			continue
+		}
+
+		val, err := spec.coerce(val)
+		if err != nil {
+			return fmt.Errorf("invalid prop '%s': %w", spec.name, err)
+		}
+		if len(spec.enum) > 0 && !slices.ContainsFunc(spec.enum, func(e any) bool {
+			return fmt.Sprint(e) == fmt.Sprint(val)
+		}) {
+			return fmt.Errorf("invalid prop '%s': %v is not one of %v", spec.name, val, spec.enum)
+		}
+		vars[spec.name] = val
+	}
+	return nil
+}
+
+// coerce converts a prop value to the declared type. Attribute values are
+// strings, so strings are parsed as numbers and booleans.
+func (spec *propSpec) coerce(val any) (any, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(val)
+	switch spec.typ {
+	case "string":
+		switch rv.Kind() {
+		case reflect.String:
+			return rv.String(), nil
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			return fmt.Sprint(val), nil
+		}
+	case "int":
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return int(rv.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return int(rv.Uint()), nil
+		case reflect.Float32, reflect.Float64:
+			if f := rv.Float(); f == float64(int(f)) {
+				return int(f), nil
+			}
+		case reflect.String:
+			if i, err := strconv.Atoi(strings.TrimSpace(rv.String())); err == nil {
+				return i, nil
+			}
+		}
+	case "float":
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(rv.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return float64(rv.Uint()), nil
+		case reflect.Float32, reflect.Float64:
+			return rv.Float(), nil
+		case reflect.String:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64); err == nil {
+				return f, nil
+			}
+		}
+	case "bool":
+		switch rv.Kind() {
+		case reflect.Bool:
+			return rv.Bool(), nil
+		case reflect.String:
+			// A present attribute without a value, like `<my-input disabled>`, is true
+			switch s := strings.TrimSpace(rv.String()); s {
+			case "", "true", spec.name:
+				return true, nil
+			case "false":
+				return false, nil
+			}
+		}
+	case "array":
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			return val, nil
+		}
+	case "object":
+		if rv.Kind() == reflect.Map || rv.Kind() == reflect.Struct || (rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Struct) {
+			return val, nil
+		}
+	default:
+		return val, nil
+	}
+	return nil, fmt.Errorf("expected %s, got %T %v", spec.typ, val, val)
+}
@@ -0,0 +1,135 @@
+package vuego_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+var propsFS = fstest.MapFS{
+	"components/UiButton.vuego": &fstest.MapFile{Data: []byte(`---
+props:
+  label:
+    type: string
+    required: true
+  size:
+    type: string
+    default: md
+    enum: [sm, md, lg]
+  count: int
+  disabled: bool
+---
+<button :class="'btn-' + size" :disabled="disabled">{{ label }}{{ count != nil ? ' (' + string(count + 1) + ')' : '' }}</button>`)},
+	"components/Panel.vuego": &fstest.MapFile{Data: []byte(`---
+props:
+  open:
+    type: bool
+    default: true
+---
+<details :open="open">x</details>`)},
+	"components/Avatar.vuego": &fstest.MapFile{Data: []byte(`<template :props="{user: {type: 'object', required: true}, ratio: {type: 'float', default: 1}}"><img :alt="user.name" :data-ratio="ratio * 2"></template>`)},
+}
+
+func TestComponentProps(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "defaults and coercion",
+			template: `<ui-button label="Save" count="2" disabled></ui-button>`,
+			want:     `<button class="btn-md" disabled>Save (3)</button>`,
+		},
+		{
+			name:     "bound values",
+			template: `<ui-button :label="text" size="lg" :count="n" disabled="false"></ui-button>`,
+			data:     map[string]any{"text": "Go", "n": 4},
+			want:     `<button class="btn-lg">Go (5)</button>`,
+		},
+		{
+			name:     "template declaration",
+			template: `<avatar :user="user" ratio="1.5"></avatar><avatar :user="user"></avatar>`,
+			data:     map[string]any{"user": map[string]any{"name": "Ana"}},
+			want:     `<img alt="Ana" data-ratio="3"><img alt="Ana" data-ratio="2">`,
+		},
+		{
+			name:     "false bindings are passed",
+			template: `<panel></panel><panel :open="no"></panel><panel :open="yes"></panel>`,
+			data:     map[string]any{"no": false, "yes": true},
+			want:     `<details open>x</details><details>x</details><details open>x</details>`,
+		},
+		{
+			name:     "undeclared attributes fall through",
+			template: `<ui-button label="Save" type="submit"></ui-button>`,
+			want:     `<button class="btn-md" type="submit">Save</button>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			propsFS["page.vuego"] = &fstest.MapFile{Data: []byte(tc.template)}
+			tpl := vuego.NewFS(propsFS, vuego.WithComponents(), vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestComponentProps_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		strict   bool
+		want     string
+	}{
+		{
+			name:     "required",
+			template: `<ui-button size="sm"></ui-button>`,
+			want:     "error in components/UiButton.vuego (included from page.vuego): required prop 'label' not provided",
+		},
+		{
+			name:     "enum",
+			template: `<ui-button label="a" size="xl"></ui-button>`,
+			want:     "invalid prop 'size': xl is not one of [sm md lg]",
+		},
+		{
+			name:     "type",
+			template: `<ui-button label="a" count="many"></ui-button>`,
+			want:     "invalid prop 'count': expected int, got string many",
+		},
+		{
+			name:     "object type",
+			template: `<avatar user="ana"></avatar>`,
+			want:     "invalid prop 'user': expected object, got string ana",
+		},
+		{
+			name:     "undeclared in strict mode",
+			template: `<ui-button label="a" colour="red" class="ok"></ui-button>`,
+			strict:   true,
+			want:     "undeclared prop 'colour' for components/UiButton.vuego",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			propsFS["page.vuego"] = &fstest.MapFile{Data: []byte(tc.template)}
+			opts := []vuego.LoadOption{vuego.WithComponents()}
+			if tc.strict {
+				opts = append(opts, vuego.WithStrict())
+			}
+			tpl := vuego.NewFS(propsFS, opts...)
+
+			var buf bytes.Buffer
+			err := tpl.Load("page.vuego").Render(t.Context(), &buf)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
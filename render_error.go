@@ -0,0 +1,182 @@
+package vuego
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego/internal/helpers"
+	"github.com/titpetric/vuego/internal/parser"
+)
+
+// RenderError is returned when evaluating a template fails. It locates the
+// failing expression, attribute or element in the template source and wraps
+// the underlying error, so it can be inspected with errors.As and errors.Is.
+type RenderError struct {
+	// Template is the file containing the failing node.
+	Template string
+	// Line is the 1-based source line, counting front-matter lines.
+	Line int
+	// Column is the 1-based source column, counted in runes.
+	Column int
+	// Expression is the failing expression, if known.
+	Expression string
+	// Chain is the template inclusion chain, outermost template first.
+	Chain []string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the source position followed by the underlying error, in the
+// file:line:column form understood by editors and CI log parsers. The position
+// names the template, so the template chain is left out of the error message.
+func (e *RenderError) Error() string {
+	msg := e.Err.Error()
+	if len(e.Chain) > 0 {
+		msg = strings.ReplaceAll(msg, "in "+strings.Join(e.Chain, " -> ")+": ", "")
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.Template, e.Line, e.Column, msg)
+}
+
+// Unwrap returns the underlying error.
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+// exprError annotates an error with the interpolated expression that caused it.
+type exprError struct {
+	expr string
+	err  error
+}
+
+func (e *exprError) Error() string {
+	return fmt.Sprintf("in expression '{{ %s }}': %v", e.expr, e.err)
+}
+
+func (e *exprError) Unwrap() error {
+	return e.err
+}
+
+// attrError annotates an error with the attribute that caused it.
+type attrError struct {
+	key  string // attribute key as written, e.g. `:href`
+	name string // attribute name in the output, e.g. `href`
+	err  error
+}
+
+func (e *attrError) Error() string {
+	return fmt.Sprintf("error evaluating attr %s: %v", e.name, e.err)
+}
+
+func (e *attrError) Unwrap() error {
+	return e.err
+}
+
+// nodePosition returns the template and source position of a compiled node,
+// from the compiled templates being evaluated.
+func (ctx VueContext) nodePosition(node *html.Node) (string, *parser.NodePos, bool) {
+	// Copies made for node processors map back to the compiled nodes
+	for source, ok := ctx.sources[node]; ok; source, ok = ctx.sources[node] {
+		node = source
+	}
+	for _, c := range slices.Backward(ctx.compiled) {
+		if pos, ok := c.positions[node]; ok {
+			return c.filename, pos, true
+		}
+	}
+	return "", nil, false
+}
+
+// mapSources records the compiled node each node of a deep copy was cloned from.
+func mapSources(sources map[*html.Node]*html.Node, clone, node *html.Node) {
+	sources[clone] = node
+	for c, n := clone.FirstChild, node.FirstChild; c != nil && n != nil; c, n = c.NextSibling, n.NextSibling {
+		mapSources(sources, c, n)
+	}
+}
+
+// nodeError wraps err in a *RenderError located at node. Errors that already
+// carry a position are returned as is, so the innermost position wins. Nodes
+// without a known position, like copies made by directives, leave err unchanged
+// for the caller evaluating the compiled node to wrap.
+func (v *Vue) nodeError(ctx VueContext, node *html.Node, err error) error {
+	if err == nil {
+		return nil
+	}
+	var renderErr *RenderError
+	if errors.As(err, &renderErr) {
+		return err
+	}
+
+	template, nodePos, ok := ctx.nodePosition(node)
+	if !ok {
+		return err
+	}
+
+	pos := nodePos.Pos
+	expression := errorExpression(err)
+
+	var attrErr *attrError
+	switch {
+	case node.Type == html.TextNode:
+		if p, ok := nodePos.Exprs[expression]; ok {
+			pos = p
+		}
+	case errors.As(err, &attrErr):
+		if p, ok := nodePos.Attrs[attrErr.key]; ok {
+			pos = p
+		}
+		if expression == "" {
+			expression = strings.TrimSpace(helpers.GetAttr(node, attrErr.key))
+		}
+	default:
+		if p, ok := nodePos.Attrs[exprAttr(node, expression)]; ok {
+			pos = p
+		}
+	}
+
+	return &RenderError{
+		Template:   template,
+		Line:       pos.Line,
+		Column:     pos.Column,
+		Expression: expression,
+		Chain:      slices.Clone(ctx.TemplateStack),
+		Err:        err,
+	}
+}
+
+// errorExpression returns the template expression an error was annotated with.
+// The interpolated expression is preferred over the part of it that failed.
+func errorExpression(err error) string {
+	var exprErr *exprError
+	if errors.As(err, &exprErr) {
+		return exprErr.expr
+	}
+	var strictErr *StrictError
+	if errors.As(err, &strictErr) {
+		return strictErr.Expression
+	}
+	return ""
+}
+
+// exprAttr returns the key of the attribute holding expression, preferring
+// an exact match over one that contains it, like the collection of a v-for.
+func exprAttr(node *html.Node, expression string) string {
+	if expression == "" {
+		return ""
+	}
+	for _, attr := range node.Attr {
+		if strings.TrimSpace(attr.Val) == expression {
+			return attr.Key
+		}
+	}
+	for _, attr := range node.Attr {
+		if strings.Contains(attr.Val, expression) {
+			return attr.Key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package vuego_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestRenderError_Position(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`---
+title: Page
+---
+<main>
+  <template include="card.vuego" :items="items"></template>
+</main>`)},
+		"card.vuego": &fstest.MapFile{Data: []byte(`<ul>
+  <li v-for="item in items"
+      :title="item | nope">{{ item }}</li>
+</ul>
+<ol v-for="row in rows">
+  <li>{{ row | bad }}</li>
+</ol>
+<p>{{ title }} {{ title | missing }}</p>`)},
+	}
+
+	tests := []struct {
+		name       string
+		data       map[string]any
+		line       int
+		column     int
+		expression string
+	}{
+		{"bound attribute in loop", map[string]any{"items": []string{"a"}}, 3, 7, "item | nope"},
+		{"interpolation in loop", map[string]any{"items": []string{}, "rows": []string{"a"}}, 6, 7, "row | bad"},
+		{"second interpolation", map[string]any{"items": []string{}}, 8, 16, "title | missing"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := vuego.NewVue(templateFS).Render(t.Context(), &buf, "page.vuego", tc.data)
+			assert.Error(t, err)
+
+			var renderErr *vuego.RenderError
+			assert.True(t, errors.As(err, &renderErr), "expected RenderError, got %v", err)
+			assert.Equal(t, "card.vuego", renderErr.Template)
+			assert.Equal(t, tc.line, renderErr.Line)
+			assert.Equal(t, tc.column, renderErr.Column)
+			assert.Equal(t, tc.expression, renderErr.Expression)
+			assert.Equal(t, []string{"page.vuego", "card.vuego"}, renderErr.Chain)
+			assert.NotNil(t, errors.Unwrap(renderErr))
+		})
+	}
+}
+
+func TestRenderError_FrontMatterOffset(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte("---\ntitle: Page\nauthor: Ana\n---\n<h1>{{ title }}</h1>\n<p v-if=\"ready\">{{ author | shout }}</p>")},
+	}
+
+	var buf bytes.Buffer
+	err := vuego.NewVue(templateFS).Render(t.Context(), &buf, "page.vuego", map[string]any{"ready": true})
+	assert.Error(t, err)
+	assert.Equal(t, "page.vuego:6:17: in expression '{{ author | shout }}': function 'shout' not found", err.Error())
+}
+
+func TestRenderError_Strict(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte("<div>\n  <p v-if=\"ok\">yes</p>\n  <p v-else-if=\"user.nmae\">no</p>\n</div>")},
+	}
+
+	data := map[string]any{"ok": false, "user": map[string]any{"name": "Ana"}}
+
+	var buf bytes.Buffer
+	tpl := vuego.NewFS(templateFS, vuego.WithStrict())
+	err := tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf)
+	assert.Error(t, err)
+
+	var renderErr *vuego.RenderError
+	assert.True(t, errors.As(err, &renderErr), "expected RenderError, got %v", err)
+	assert.Equal(t, 3, renderErr.Line)
+	assert.Equal(t, 6, renderErr.Column)
+	assert.Equal(t, "user.nmae", renderErr.Expression)
+
+	var strictErr *vuego.StrictError
+	assert.True(t, errors.As(err, &strictErr))
+}
+
+func TestRenderError_RenderString(t *testing.T) {
+	var buf bytes.Buffer
+	err := vuego.New().RenderString(t.Context(), &buf, "<div>\n  <b>{{ 1 | nope }}</b>\n</div>")
+	assert.Error(t, err)
+
+	var renderErr *vuego.RenderError
+	assert.True(t, errors.As(err, &renderErr), "expected RenderError, got %v", err)
+	assert.Equal(t, 2, renderErr.Line)
+	assert.Equal(t, 6, renderErr.Column)
+}
+
+func TestRenderError_WithProcessor(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte("<div>\n  <b :class=\"kind | nope\">x</b>\n</div>")},
+	}
+
+	var instances int
+	tpl := vuego.NewFS(templateFS, vuego.WithProcessor(&layoutProcessor{instances: &instances}))
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Fill(map[string]any{"kind": "a"}).Render(t.Context(), &buf)
+	assert.Error(t, err)
+
+	var renderErr *vuego.RenderError
+	assert.True(t, errors.As(err, &renderErr), "expected RenderError, got %v", err)
+	assert.Equal(t, 2, renderErr.Line)
+	assert.Equal(t, 6, renderErr.Column)
+	assert.Equal(t, "kind | nope", renderErr.Expression)
+}
@@ -0,0 +1,197 @@
+package vuego
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego/internal/helpers"
+)
+
+// compactRenderer writes nodes without adding indentation or line breaks.
+// It backs both the minifying and the faithful renderer, which differ in
+// how they treat whitespace, comments and attribute quotes.
+type compactRenderer struct {
+	minify bool
+}
+
+// NewMinifyRenderer creates a Renderer that produces small output.
+// It collapses whitespace outside of preformatted elements, strips comments
+// and leaves attribute values unquoted where HTML allows it.
+func NewMinifyRenderer() Renderer {
+	return &compactRenderer{minify: true}
+}
+
+// NewFaithfulRenderer creates a Renderer that keeps the original whitespace,
+// comments and attribute order of the template, without re-indenting the output.
+func NewFaithfulRenderer() Renderer {
+	return &compactRenderer{}
+}
+
+// Render renders HTML nodes to the given writer.
+func (r *compactRenderer) Render(ctx context.Context, w io.Writer, nodes []*html.Node) error {
+	if r.minify {
+		w = &trimStartWriter{w: w}
+	}
+	bw := bufio.NewWriter(w)
+	for _, node := range nodes {
+		r.renderNode(bw, node, "")
+	}
+	return bw.Flush()
+}
+
+// trimStartWriter drops whitespace written before the first other byte,
+// so minified output doesn't start with the collapsed leading text.
+type trimStartWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func (t *trimStartWriter) Write(p []byte) (int, error) {
+	if t.started {
+		return t.w.Write(p)
+	}
+	n := len(p)
+	p = bytes.TrimLeft(p, " \t\n\r\f")
+	if len(p) == 0 {
+		return n, nil
+	}
+	t.started = true
+	if _, err := t.w.Write(p); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// renderNode writes node. The pre argument names the enclosing preformatted
+// element, if any, which decides how text is escaped and whether whitespace is kept.
+func (r *compactRenderer) renderNode(w *bufio.Writer, node *html.Node, pre string) {
+	switch node.Type {
+	case html.DoctypeNode:
+		_, _ = w.WriteString("<!DOCTYPE " + node.Data + ">")
+
+	case html.CommentNode:
+		if !r.minify {
+			_, _ = w.WriteString("<!--" + node.Data + "-->")
+		}
+
+	case html.TextNode:
+		data := node.Data
+		if r.minify && pre == "" {
+			data = collapseWhitespace(data)
+		}
+		_, _ = w.WriteString(escapeText(data, helpers.IsRawTextElement(pre)))
+
+	case html.RawNode:
+		_, _ = w.WriteString(node.Data)
+
+	case html.ElementNode:
+		tagName := node.Data
+
+		// Templates are replaced by their content, unless v-keep is set
+		keep := helpers.HasAttr(node, "v-keep")
+		if tagName != "template" || keep {
+			attrs := node.Attr
+			if keep {
+				attrs = helpers.FilterAttrs(attrs, "v-keep")
+			}
+			_, _ = w.WriteString("<" + tagName)
+			r.renderAttrs(w, attrs)
+			_, _ = w.WriteString(">")
+
+			if helpers.IsVoidElement(tagName) {
+				return
+			}
+		}
+
+		if pre == "" && helpers.IsPreformattedElement(tagName) {
+			pre = tagName
+			// The parser drops a newline directly after the start tag, write it back so it isn't lost
+			if tagName == "pre" || tagName == "textarea" || tagName == "listing" {
+				if c := node.FirstChild; c != nil && c.Type == html.TextNode && strings.HasPrefix(c.Data, "\n") {
+					_, _ = w.WriteString("\n")
+				}
+			}
+		}
+
+		if content := evaluatedContent(node); content != "" {
+			_, _ = w.WriteString(content)
+		} else {
+			for c := node.FirstChild; c != nil; c = c.NextSibling {
+				r.renderNode(w, c, pre)
+			}
+		}
+
+		if tagName != "template" || keep {
+			_, _ = w.WriteString("</" + tagName + ">")
+		}
+
+	default:
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			r.renderNode(w, c, pre)
+		}
+	}
+}
+
+// renderAttrs writes attributes in their original order, skipping directives.
+func (r *compactRenderer) renderAttrs(w *bufio.Writer, attrs []html.Attribute) {
+	for _, a := range attrs {
+		key := a.Key
+		if shouldIgnoreAttr(key) {
+			continue
+		}
+		if isLiteralAttr(key) {
+			key = key[1 : len(key)-1]
+		}
+
+		_, _ = w.WriteString(" " + key)
+		// Boolean attributes are true by presence, so they are written without a value
+		if helpers.IsBooleanAttr(key) && (a.Val == "" || strings.EqualFold(a.Val, key)) {
+			continue
+		}
+
+		val := escapeAttrValue(a.Val)
+		if r.minify {
+			// An empty value is the same as no value
+			if val == "" {
+				continue
+			}
+			if !strings.ContainsAny(val, " \t\n\r\f\"'=<>`") {
+				_, _ = w.WriteString("=" + val)
+				continue
+			}
+		}
+		_, _ = w.WriteString(`="` + val + `"`)
+	}
+}
+
+// collapseWhitespace replaces each run of whitespace in s with a single space.
+func collapseWhitespace(s string) string {
+	if !strings.ContainsAny(s, " \t\n\r\f") {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	space := false
+	for _, c := range s {
+		switch c {
+		case ' ', '\t', '\n', '\r', '\f':
+			space = true
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		sb.WriteRune(c)
+	}
+	if space {
+		sb.WriteByte(' ')
+	}
+	return sb.String()
+}
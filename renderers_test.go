@@ -0,0 +1,83 @@
+package vuego_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+const rendererTemplate = `<!-- card -->
+<div   class="card  {{ kind }}" :id="id" data-empty="" title="a b">
+  <h1>{{ title }}</h1>
+  <input type="checkbox" :checked="done" value="x=1">
+  <pre>
+  keep   this
+</pre>
+  <template v-if="done"><b>done</b></template>
+</div>`
+
+func TestRenderer_Minify(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(rendererTemplate)},
+	}
+	data := map[string]any{"kind": "big", "id": "c1", "title": "Hello  world", "done": true}
+
+	tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewMinifyRenderer()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf))
+
+	want := `<div class="card  big" id=c1 data-empty title="a b"> <h1>Hello world</h1> <input type=checkbox checked value="x=1"> <pre>  keep   this
+</pre> <b>done</b> </div>`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestRenderer_MinifyLeadingWhitespace(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte("\n  \n<p>{{ text }}</p>\n")},
+	}
+
+	tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewMinifyRenderer()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Fill(map[string]any{"text": "hi"}).Render(t.Context(), &buf))
+	assert.Equal(t, "<p>hi</p> ", buf.String())
+}
+
+func TestRenderer_Faithful(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(rendererTemplate)},
+	}
+	data := map[string]any{"kind": "big", "id": "c1", "title": "Hello  world", "done": true}
+
+	tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf))
+
+	want := `<!-- card -->
+<div class="card  big" id="c1" data-empty="" title="a b">
+  <h1>Hello  world</h1>
+  <input type="checkbox" checked value="x=1">
+  <pre>  keep   this
+</pre>
+  <b>done</b>
+</div>`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestRenderer_LayoutChain(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego":         &fstest.MapFile{Data: []byte("---\nlayout: base\n---\n<p>\n  {{ title }}\n</p>")},
+		"layouts/base.vuego": &fstest.MapFile{Data: []byte("<main>\n  <template v-html=\"content\"></template>\n</main>")},
+	}
+
+	tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewMinifyRenderer()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Fill(map[string]any{"title": "Hi"}).Render(t.Context(), &buf))
+	assert.Equal(t, "<main> <p> Hi </p> </main>", buf.String())
+}
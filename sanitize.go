@@ -0,0 +1,208 @@
+package vuego
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/titpetric/vuego/internal/helpers"
+)
+
+// DefaultSanitizePolicyName is the name of the policy used by v-html-safe,
+// and by the sanitize filter when no policy is named.
+const DefaultSanitizePolicyName = "default"
+
+// SanitizePolicy is an allowlist of the markup kept by the sanitize filter
+// and the v-html-safe directive. Elements that are not allowed are removed and
+// their content is kept, except for elements like <script> and <style> which
+// are removed with their content. Comments are always removed.
+type SanitizePolicy struct {
+	// Tags are the allowed elements.
+	Tags []string
+	// Attrs are the allowed attributes per element.
+	// Attributes listed under "*" are allowed on every allowed element.
+	Attrs map[string][]string
+	// URLSchemes are the schemes allowed in URL attributes like href and src.
+	// Attributes with any other scheme are removed. Relative URLs are always allowed.
+	URLSchemes []string
+	// LinkRel, if set, replaces the rel attribute of links with a href,
+	// e.g. "nofollow noopener".
+	LinkRel string
+}
+
+// DefaultSanitizePolicy returns a policy suited for user generated content like
+// comments and rendered Markdown. It allows text formatting, lists, tables,
+// links and images, and marks links with rel="nofollow noopener".
+func DefaultSanitizePolicy() *SanitizePolicy {
+	return &SanitizePolicy{
+		Tags: []string{
+			"a", "abbr", "b", "blockquote", "br", "code", "dd", "del", "div", "dl", "dt", "em",
+			"h1", "h2", "h3", "h4", "h5", "h6", "hr", "i", "img", "ins", "kbd", "li", "mark",
+			"ol", "p", "pre", "q", "s", "small", "span", "strong", "sub", "sup",
+			"table", "tbody", "td", "tfoot", "th", "thead", "tr", "u", "ul",
+		},
+		Attrs: map[string][]string{
+			"*":    {"title", "lang", "dir"},
+			"a":    {"href"},
+			"code": {"class"},
+			"img":  {"src", "alt", "width", "height"},
+			"ol":   {"start"},
+			"td":   {"colspan", "rowspan"},
+			"th":   {"colspan", "rowspan", "scope"},
+		},
+		URLSchemes: []string{"http", "https", "mailto"},
+		LinkRel:    "nofollow noopener",
+	}
+}
+
+// WithSanitizePolicy returns a LoadOption that registers a named policy for
+// the sanitize filter, e.g. `{{ body | sanitize("comments") }}`. Registering
+// DefaultSanitizePolicyName replaces the policy used by default and by v-html-safe.
+func WithSanitizePolicy(name string, policy *SanitizePolicy) LoadOption {
+	return func(vue *Vue) {
+		if vue.sanitizePolicies == nil {
+			vue.sanitizePolicies = make(map[string]*SanitizePolicy)
+		}
+		vue.sanitizePolicies[name] = policy
+	}
+}
+
+// defaultSanitizePolicy is used when no default policy is registered.
+var defaultSanitizePolicy = DefaultSanitizePolicy()
+
+// sanitizePolicy returns the named policy, or the default policy for an empty name.
+func (v *Vue) sanitizePolicy(name string) (*SanitizePolicy, error) {
+	if name == "" {
+		name = DefaultSanitizePolicyName
+	}
+	if policy, ok := v.sanitizePolicies[name]; ok {
+		return policy, nil
+	}
+	if name == DefaultSanitizePolicyName {
+		return defaultSanitizePolicy, nil
+	}
+	return nil, fmt.Errorf("unknown sanitize policy '%s'", name)
+}
+
+// sanitizeFunc returns the sanitize filter, which cleans an HTML string with
+// the named policy and returns it as trusted HTML.
+func sanitizeFunc(v *Vue) func(any, ...string) (HTML, error) {
+	return func(value any, policy ...string) (HTML, error) {
+		name := ""
+		if len(policy) > 0 {
+			name = policy[0]
+		}
+		p, err := v.sanitizePolicy(name)
+		if err != nil {
+			return "", err
+		}
+		if value == nil {
+			return "", nil
+		}
+		return p.Sanitize(fmt.Sprint(value)), nil
+	}
+}
+
+// Sanitize parses input as an HTML fragment and returns it with everything
+// the policy doesn't allow removed.
+func (p *SanitizePolicy) Sanitize(input string) HTML {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(input), context)
+	if err != nil {
+		// The parser only fails on reader errors, fall back to plain text
+		return HTML(html.EscapeString(input))
+	}
+
+	var sb strings.Builder
+	for _, node := range nodes {
+		p.sanitizeNode(&sb, node)
+	}
+	return HTML(sb.String())
+}
+
+// sanitizeDropContent are elements removed together with their content.
+var sanitizeDropContent = map[string]bool{
+	"script": true, "style": true, "template": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "noembed": true, "noframes": true, "textarea": true, "title": true,
+	"select": true, "svg": true, "math": true, "xmp": true, "plaintext": true,
+}
+
+func (p *SanitizePolicy) sanitizeNode(sb *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		sb.WriteString(html.EscapeString(node.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	tag := node.Data
+	if sanitizeDropContent[tag] {
+		return
+	}
+	allowed := slices.Contains(p.Tags, tag)
+
+	if allowed {
+		sb.WriteString("<" + tag)
+		for _, attr := range p.sanitizeAttrs(node) {
+			sb.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+		}
+		sb.WriteString(">")
+		if helpers.IsVoidElement(tag) {
+			return
+		}
+	}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		p.sanitizeNode(sb, c)
+	}
+
+	if allowed {
+		sb.WriteString("</" + tag + ">")
+	}
+}
+
+// sanitizeAttrs returns the attributes of node allowed by the policy.
+func (p *SanitizePolicy) sanitizeAttrs(node *html.Node) []html.Attribute {
+	var (
+		result []html.Attribute
+		link   bool
+	)
+	for _, attr := range node.Attr {
+		if attr.Namespace != "" {
+			continue
+		}
+		if !slices.Contains(p.Attrs[node.Data], attr.Key) && !slices.Contains(p.Attrs["*"], attr.Key) {
+			continue
+		}
+		if urlAttrs[attr.Key] {
+			if !p.allowURL(attr.Val) {
+				continue
+			}
+			link = link || (node.Data == "a" && attr.Key == "href")
+		}
+		if attr.Key == "rel" && p.LinkRel != "" && node.Data == "a" {
+			continue
+		}
+		result = append(result, attr)
+	}
+	if link && p.LinkRel != "" {
+		result = append(result, html.Attribute{Key: "rel", Val: p.LinkRel})
+	}
+	return result
+}
+
+// allowURL reports whether a URL is relative or uses an allowed scheme.
+func (p *SanitizePolicy) allowURL(value string) bool {
+	value = strings.TrimSpace(value)
+	i := strings.IndexAny(value, ":/?#")
+	if i < 0 || value[i] != ':' {
+		return true
+	}
+	scheme := strings.ToLower(value[:i])
+	return slices.Contains(p.URLSchemes, scheme)
+}
@@ -0,0 +1,87 @@
+package vuego_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestSanitizePolicy_Sanitize(t *testing.T) {
+	policy := vuego.DefaultSanitizePolicy()
+
+	tests := []struct {
+		name  string
+		input string
+		want  vuego.HTML
+	}{
+		{
+			name:  "allowed markup is kept",
+			input: `<p>Hello <strong>world</strong><br></p>`,
+			want:  `<p>Hello <strong>world</strong><br></p>`,
+		},
+		{
+			name:  "scripts are removed with their content",
+			input: `<p>a<script>alert(1)</script><style>p{}</style>b</p>`,
+			want:  `<p>ab</p>`,
+		},
+		{
+			name:  "unknown elements are unwrapped",
+			input: `<section><blink>text</blink></section><!-- note -->`,
+			want:  `text`,
+		},
+		{
+			name:  "attributes are filtered",
+			input: `<p class="x" title="t" onclick="alert(1)">a</p><img src="/a.png" alt="A" onerror="x">`,
+			want:  `<p title="t">a</p><img src="/a.png" alt="A">`,
+		},
+		{
+			name:  "unsafe urls are removed",
+			input: `<a href="javascript:alert(1)">a</a><a href=" JAVASCRIPT:x">b</a><img src="data:image/png;base64,xx">`,
+			want:  `<a>a</a><a>b</a><img>`,
+		},
+		{
+			name:  "links get rel",
+			input: `<a href="https://example.com" rel="author">x</a><a href="/about">y</a>`,
+			want:  `<a href="https://example.com" rel="nofollow noopener">x</a><a href="/about" rel="nofollow noopener">y</a>`,
+		},
+		{
+			name:  "text is escaped",
+			input: `1 &lt; 2 & "quoted"`,
+			want:  `1 &lt; 2 &amp; &#34;quoted&#34;`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, policy.Sanitize(tc.input))
+		})
+	}
+}
+
+func TestSanitize_Template(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<div v-html-safe="body"></div><div>{{ body | sanitize }}</div><div>{{ body | sanitize("text") }}</div>`)},
+	}
+	strip := &vuego.SanitizePolicy{}
+	tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()), vuego.WithSanitizePolicy("text", strip))
+
+	var buf bytes.Buffer
+	data := map[string]any{"body": `<b onclick="x()">hi</b><script>x()</script>`}
+	assert.NoError(t, tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf))
+	assert.Equal(t, `<div><b>hi</b></div><div><b>hi</b></div><div>hi</div>`, buf.String())
+}
+
+func TestSanitize_UnknownPolicy(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<div>{{ body | sanitize("missing") }}</div>`)},
+	}
+	tpl := vuego.NewFS(templateFS)
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Fill(map[string]any{"body": "x"}).Render(t.Context(), &buf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown sanitize policy 'missing'")
+}
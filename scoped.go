@@ -0,0 +1,374 @@
+package vuego
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego/internal/helpers"
+)
+
+// scopeAttr returns the attribute that scopes the styles of a template,
+// e.g. `data-v-1a2b3c4d`. It is derived from the filename, so it is stable.
+func scopeAttr(filename string) string {
+	h := fnv.New32a()
+	h.Write([]byte(filename))
+	return fmt.Sprintf("data-v-%08x", h.Sum32())
+}
+
+// scopeTemplate prepares a compiled template with a `<style scoped>` block.
+// Every element of the template gets the scope attribute of the template, and
+// the scoped style is marked with the attribute, see scopeStyles.
+//
+// Component tags get the attribute too, so it falls through to the root element
+// of the component. Slot content belongs to the template that passes it.
+func (v *Vue) scopeTemplate(filename string, dom []*html.Node) {
+	if !slices.ContainsFunc(dom, hasScopedStyle) {
+		return
+	}
+	attr := scopeAttr(filename)
+	for _, node := range dom {
+		v.scopeNode(node, attr)
+	}
+}
+
+func hasScopedStyle(node *html.Node) bool {
+	if node.Type != html.ElementNode {
+		return false
+	}
+	if node.Data == "style" && helpers.HasAttr(node, "scoped") {
+		return true
+	}
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if hasScopedStyle(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Vue) scopeNode(node *html.Node, attr string) {
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	switch node.Data {
+	case "style":
+		if helpers.HasAttr(node, "scoped") {
+			helpers.SetAttr(node, "scoped", attr)
+		}
+		return
+	case "script":
+		return
+	case "slot":
+	case "template":
+		if helpers.HasAttr(node, "include") {
+			node.Attr = append(node.Attr, html.Attribute{Key: attr})
+		}
+	default:
+		if _, ok := v.goComponents[node.Data]; !ok {
+			node.Attr = append(node.Attr, html.Attribute{Key: attr})
+		}
+	}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		v.scopeNode(c, attr)
+	}
+}
+
+// scopeStyles rewrites the selectors of the scoped styles in the rendered nodes
+// to match the scope attribute of their template. Each scoped style is kept
+// only once, so a component used many times emits its style once per render.
+// It runs after the node processors, so LESS is compiled first.
+func scopeStyles(nodes []*html.Node) []*html.Node {
+	seen := make(map[string]bool)
+	return scopeStyleNodes(nodes, seen)
+}
+
+func scopeStyleNodes(nodes []*html.Node, seen map[string]bool) []*html.Node {
+	result := nodes[:0]
+	for _, node := range nodes {
+		if scopeStyleNode(node, seen) {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// scopeStyleNode scopes the style node, or the styles in its children.
+// It returns false if the node is a scoped style that was already emitted.
+func scopeStyleNode(node *html.Node, seen map[string]bool) bool {
+	if node.Type != html.ElementNode {
+		return true
+	}
+
+	if node.Data == "style" && helpers.HasAttr(node, "scoped") {
+		attr := helpers.GetAttr(node, "scoped")
+		helpers.RemoveAttr(node, "scoped")
+		if attr == "" {
+			return true
+		}
+		if seen[attr] {
+			return false
+		}
+		seen[attr] = true
+
+		var css strings.Builder
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.TextNode {
+				css.WriteString(c.Data)
+			}
+		}
+		text := &html.Node{Type: html.TextNode, Data: scopeCSS(css.String(), attr), Parent: node}
+		node.FirstChild, node.LastChild = text, text
+		return true
+	}
+
+	var children []*html.Node
+	removed := false
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if scopeStyleNode(c, seen) {
+			children = append(children, c)
+		} else {
+			removed = true
+		}
+	}
+	if removed {
+		helpers.SetChildren(node, children)
+	}
+	return true
+}
+
+// scopedAtRules are the at-rules that contain style rules to scope.
+var scopedAtRules = map[string]bool{
+	"media": true, "supports": true, "container": true, "layer": true, "document": true,
+}
+
+// scopeCSS adds the attribute selector for attr to every selector in css,
+// e.g. `.btn:hover` becomes `.btn[data-v-1a2b3c4d]:hover`. Descendants of
+// `:deep(...)` are not scoped, e.g. `.list :deep(a)` becomes `.list[data-v-1a2b3c4d] a`.
+func scopeCSS(css, attr string) string {
+	var sb strings.Builder
+	scopeRules(&sb, css, "["+attr+"]")
+	return sb.String()
+}
+
+func scopeRules(sb *strings.Builder, css, sel string) {
+	for len(css) > 0 {
+		i := cssIndex(css, 0, "{;}")
+		if i < 0 {
+			sb.WriteString(css)
+			return
+		}
+		if css[i] != '{' {
+			// Statements like @import and stray closing braces
+			sb.WriteString(css[:i+1])
+			css = css[i+1:]
+			continue
+		}
+
+		prelude := css[:i]
+		end := cssBlockEnd(css, i)
+		block := css[i+1 : end]
+
+		if trimmed := strings.TrimSpace(stripCSSComments(prelude)); strings.HasPrefix(trimmed, "@") {
+			name := strings.ToLower(strings.TrimLeft(trimmed, "@"))
+			if j := strings.IndexAny(name, " \t\r\n("); j >= 0 {
+				name = name[:j]
+			}
+			sb.WriteString(prelude + "{")
+			if scopedAtRules[name] {
+				scopeRules(sb, block, sel)
+			} else {
+				// @keyframes, @font-face and @page contain no selectors
+				sb.WriteString(block)
+			}
+		} else {
+			sb.WriteString(scopeSelectors(stripCSSComments(prelude), sel) + "{" + block)
+		}
+
+		if end < len(css) {
+			sb.WriteByte('}')
+			end++
+		}
+		css = css[end:]
+	}
+}
+
+// scopeSelectors scopes each selector in a comma separated selector list.
+func scopeSelectors(list, sel string) string {
+	parts := cssSplit(list, ',')
+	for i, part := range parts {
+		trimmed := strings.TrimSpace(part)
+		if trimmed == "" {
+			continue
+		}
+		lead := part[:strings.Index(part, trimmed)]
+		trail := part[len(lead)+len(trimmed):]
+		parts[i] = lead + scopeSelector(trimmed, sel) + trail
+	}
+	return strings.Join(parts, ",")
+}
+
+func scopeSelector(s, sel string) string {
+	if i := strings.Index(s, ":deep("); i >= 0 {
+		j := cssIndex(s, i+len(":deep("), ")")
+		if j < 0 {
+			j = len(s)
+		}
+		inner := strings.TrimSpace(s[i+len(":deep(") : j])
+		var after string
+		if j < len(s) {
+			after = s[j+1:]
+		}
+		before := strings.TrimSpace(s[:i])
+		if before == "" {
+			return sel + " " + inner + after
+		}
+		return scopeCompound(before, sel) + " " + inner + after
+	}
+	return scopeCompound(s, sel)
+}
+
+// scopeCompound adds sel to the last compound selector of s, before any pseudo-classes.
+func scopeCompound(s, sel string) string {
+	start, pseudo := 0, -1
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			i++
+		case '"', '\'':
+			i = cssStringEnd(s, i)
+		case '(', '[':
+			end := ")"
+			if c == '[' {
+				end = "]"
+			}
+			if i = cssIndex(s, i+1, end); i < 0 {
+				return s + sel
+			}
+		case ' ', '\t', '\r', '\n', '>', '+', '~':
+			start, pseudo = i+1, -1
+		case ':':
+			if pseudo < 0 {
+				pseudo = i
+			}
+		}
+	}
+	if pseudo >= start {
+		return s[:pseudo] + sel + s[pseudo:]
+	}
+	return s + sel
+}
+
+// cssIndex returns the index of the first byte of chars in css at or after
+// from, outside of strings, comments and nested parentheses and brackets.
+func cssIndex(css string, from int, chars string) int {
+	depth := 0
+	for i := from; i < len(css); i++ {
+		c := css[i]
+		if depth == 0 && strings.IndexByte(chars, c) >= 0 {
+			return i
+		}
+		switch c {
+		case '\\':
+			i++
+		case '"', '\'':
+			i = cssStringEnd(css, i)
+		case '/':
+			if i+1 < len(css) && css[i+1] == '*' {
+				if end := strings.Index(css[i+2:], "*/"); end >= 0 {
+					i += end + 3
+				} else {
+					i = len(css)
+				}
+			}
+		case '(', '[':
+			depth++
+		case ')', ']':
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	return -1
+}
+
+// cssBlockEnd returns the index of the brace closing the block opened at
+// css[open], or len(css) if the block is not closed.
+func cssBlockEnd(css string, open int) int {
+	depth := 0
+	for i := open; i < len(css); {
+		j := cssIndex(css, i, "{}")
+		if j < 0 {
+			return len(css)
+		}
+		if css[j] == '{' {
+			depth++
+		} else {
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+		i = j + 1
+	}
+	return len(css)
+}
+
+// cssStringEnd returns the index of the quote closing the string at css[start].
+func cssStringEnd(css string, start int) int {
+	quote := css[start]
+	for i := start + 1; i < len(css); i++ {
+		switch css[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+	return len(css)
+}
+
+// cssSplit splits css on sep outside of strings, comments, parentheses and brackets.
+func cssSplit(css string, sep byte) []string {
+	var parts []string
+	for {
+		i := cssIndex(css, 0, string(sep))
+		if i < 0 {
+			return append(parts, css)
+		}
+		parts = append(parts, css[:i])
+		css = css[i+1:]
+	}
+}
+
+func stripCSSComments(css string) string {
+	if !strings.Contains(css, "/*") {
+		return css
+	}
+	var sb strings.Builder
+	for i := 0; i < len(css); i++ {
+		switch c := css[i]; {
+		case c == '\\' && i+1 < len(css):
+			sb.WriteString(css[i : i+2])
+			i++
+		case c == '"' || c == '\'':
+			end := min(cssStringEnd(css, i), len(css)-1)
+			sb.WriteString(css[i : end+1])
+			i = end
+		case c == '/' && i+1 < len(css) && css[i+1] == '*':
+			end := strings.Index(css[i+2:], "*/")
+			if end < 0 {
+				return sb.String()
+			}
+			i += end + 3
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package vuego_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestScopedStyles(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"components/Card.vuego": &fstest.MapFile{Data: []byte(`<template :required="title"><div class="card"><h2>{{ title }}</h2><slot></slot></div><style scoped>
+.card, .card > h2:hover { color: red }
+@media (min-width: 600px) { .card::before { content: "{" } }
+@keyframes fade { from { opacity: 0 } }
+.card :deep(a) { color: blue }
+</style></template>`)},
+		"page.vuego": &fstest.MapFile{Data: []byte(`<main><card v-for="t in titles" :title="t"><p>body</p></card></main>`)},
+	}
+	tpl := vuego.NewFS(templateFS, vuego.WithComponents(), vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Fill(map[string]any{"titles": []string{"a", "b"}}).Render(t.Context(), &buf))
+
+	out := buf.String()
+	attr := out[strings.Index(out, "data-v-"):]
+	attr = attr[:strings.IndexAny(attr, `=" >`)]
+	assert.Equal(t, 15, len(attr))
+
+	want := `<main>` +
+		`<div class="card" ` + attr + `=""><h2 ` + attr + `="">a</h2><p>body</p></div><style>
+.card[` + attr + `], .card > h2[` + attr + `]:hover { color: red }
+@media (min-width: 600px) { .card[` + attr + `]::before { content: "{" } }
+@keyframes fade { from { opacity: 0 } }
+.card[` + attr + `] a { color: blue }
+</style>` +
+		`<div class="card" ` + attr + `=""><h2 ` + attr + `="">b</h2><p>body</p></div>` +
+		`</main>`
+	assert.Equal(t, want, out)
+}
+
+func TestScopedStyles_Less(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"components/Badge.vuego": &fstest.MapFile{Data: []byte(`<span class="badge">{{ text }}</span><style scoped type="text/css+less">.badge { color: red; }</style>`)},
+		"page.vuego":             &fstest.MapFile{Data: []byte(`<badge text="a"></badge><badge text="b"></badge>`)},
+	}
+	tpl := vuego.NewFS(templateFS, vuego.WithComponents(), vuego.WithLessProcessor(), vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Render(t.Context(), &buf))
+
+	out := buf.String()
+	assert.Equal(t, 1, strings.Count(out, "<style"))
+	assert.Contains(t, out, `<style type="text/css">`)
+	assert.Contains(t, out, `.badge[data-v-`)
+	assert.NotContains(t, out, "scoped")
+}
@@ -1,11 +1,17 @@
 package vuego
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"iter"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	ireflect "github.com/titpetric/vuego/internal/reflect"
 )
@@ -43,13 +49,19 @@ func getCachedPath(expr string) []string {
 }
 
 // Stack provides stack-based variable lookup and convenient typed accessors.
+// Scopes are chained from the innermost scope to the root, so Push and Pop
+// don't copy variables, and lookups walk the chain.
 type Stack struct {
-	stack    []map[string]any // bottom..top, top is last element
-	rootData any              // original data passed to Render (for struct field fallback)
+	top      *scope // innermost scope, its parent chain ends at the root
+	rootData any    // original data passed to Render (for struct field fallback)
+}
 
-	// envCache caches the flattened map from EnvMap().
-	// Invalidated on Push/Pop/Set. Stack is request-scoped so no mutex needed.
-	envCache map[string]any
+// scope is a single layer of variables in a Stack.
+type scope struct {
+	vars   map[string]any
+	parent *scope
+	// pooled is set for scopes from scopePool, which are returned on Pop.
+	pooled bool
 }
 
 // NewStack constructs a Stack with an optional initial root map (nil allowed).
@@ -60,84 +72,96 @@ func NewStack(root map[string]any) *Stack {
 
 // NewStackWithData constructs a Stack with both map data and original root data for struct field fallback.
 func NewStackWithData(root map[string]any, originalData any) *Stack {
-	s := &Stack{}
 	if root == nil {
 		root = map[string]any{}
 	}
-	s.stack = []map[string]any{root}
-	s.rootData = originalData
-	return s
+	return &Stack{
+		top:      &scope{vars: root},
+		rootData: originalData,
+	}
 }
 
-// mapPool caches map[string]any allocations to reduce GC pressure.
-var mapPool = sync.Pool{
+// scopePool caches scopes and their maps to reduce GC pressure.
+var scopePool = sync.Pool{
 	New: func() any {
-		return make(map[string]any, 0)
+		return &scope{vars: make(map[string]any), pooled: true}
 	},
 }
 
-// Copy returns a copy of the stack that can be discarded.
-// The root data is retained as is, the envmap is a copy.
+// Copy returns a copy of the stack that can be discarded. The scopes are
+// copied layer by layer, so setting variables in either stack doesn't affect
+// the other. The root data is retained as is.
 func (s *Stack) Copy() *Stack {
-	return NewStackWithData(s.EnvMap(), s.rootData)
+	c := &Stack{rootData: s.rootData}
+	next := &c.top
+	for sc := s.top; sc != nil; sc = sc.parent {
+		*next = &scope{vars: maps.Clone(sc.vars)}
+		next = &(*next).parent
+	}
+	return c
 }
 
 // Push a new map as a top-most Stack.
-// If m is nil, an empty map is obtained from the pool.
+// If m is nil, an empty scope is obtained from the pool.
 func (s *Stack) Push(m map[string]any) {
+	var sc *scope
 	if m == nil {
-		m = mapPool.Get().(map[string]any)
+		sc = scopePool.Get().(*scope)
+	} else {
+		sc = &scope{vars: m}
 	}
-	s.stack = append(s.stack, m)
-	s.envCache = nil
+	sc.parent = s.top
+	s.top = sc
 }
 
-// Pop the top-most Stack. If only root remains it still pops to empty slice safely.
-// Returns pooled maps to reduce GC pressure.
+// Pop the top-most Stack. Popping the root leaves an empty root.
+// Scopes obtained from the pool are cleared and returned to it.
 func (s *Stack) Pop() {
-	if len(s.stack) == 0 {
-		return
-	}
-	// Return the top map to the pool before removing it
-	topIdx := len(s.stack) - 1
-	topMap := s.stack[topIdx]
-	// Clear the map and return it to pool if it's not the root
-	if topIdx > 0 && len(topMap) > 0 {
-		for k := range topMap {
-			delete(topMap, k)
-		}
-		mapPool.Put(topMap)
+	sc := s.top
+	s.top = sc.parent
+	if s.top == nil {
+		s.top = &scope{vars: map[string]any{}}
 	}
-	s.stack = s.stack[:topIdx]
-	if len(s.stack) == 0 {
-		s.stack = append(s.stack, map[string]any{})
+	if sc.pooled {
+		clear(sc.vars)
+		sc.parent = nil
+		scopePool.Put(sc)
 	}
-	s.envCache = nil
 }
 
 // Set sets a key in the top-most Stack.
 func (s *Stack) Set(key string, val any) {
-	if len(s.stack) == 0 {
-		s.stack = append(s.stack, map[string]any{})
+	s.top.vars[key] = val
+}
+
+// setParent sets a key in the scope below the top-most Stack, so it outlives
+// the top-most scope. At the root, the key is set in the root.
+func (s *Stack) setParent(key string, val any) {
+	if s.top.parent == nil {
+		s.Set(key, val)
+		return
 	}
-	s.stack[len(s.stack)-1][key] = val
-	s.envCache = nil
+	s.top.parent.vars[key] = val
 }
 
 // Lookup searches stack from top to bottom for a plain identifier (no dots).
-// If not found in the stack maps, it checks the root data struct (if any).
+// If not found in the stack maps, it checks the fields and methods of the
+// root data struct (if any).
 // Returns (value, true) if found.
 func (s *Stack) Lookup(name string) (any, bool) {
-	for i := len(s.stack) - 1; i >= 0; i-- {
-		if v, ok := s.stack[i][name]; ok {
+	for sc := s.top; sc != nil; sc = sc.parent {
+		if v, ok := sc.vars[name]; ok {
 			return v, true
 		}
 	}
-	// Fallback: check root data struct fields
+	// Fallback: check root data struct fields and methods
 	if s.rootData != nil {
 		if v, ok := ireflect.ResolveValue(s.rootData, name); ok {
 			return v, true
 		}
+		if v, ok := ireflect.Member(s.rootData, name); ok {
+			return v, true
+		}
 	}
 	return nil, false
 }
@@ -288,33 +312,34 @@ func (s *Stack) GetMap(expr string) (map[string]any, bool) {
 	}
 }
 
-// EnvMap converts the Stack to a map[string]any for expr evaluation.
-// Includes all accessible values from stack and struct fields.
-// The result is cached and reused until the stack is mutated via Push/Pop/Set.
+// EnvMap flattens the Stack into a map[string]any, with inner scopes
+// overriding outer ones. It includes the struct fields and methods of the
+// root data. Expressions don't use it, they look up variables as they are
+// accessed, see exprEnv.
 func (s *Stack) EnvMap() map[string]any {
-	if s.envCache != nil {
-		return s.envCache
-	}
-
 	result := make(map[string]any)
-	// Iterate through stack from bottom to top, with top overriding bottom
-	for i := 0; i < len(s.stack); i++ {
-		for k, v := range s.stack[i] {
-			result[k] = v
-		}
-	}
 
-	// Also include struct fields from rootData (if available)
+	// Also include struct fields and methods from rootData (if available)
 	if s.rootData != nil {
 		ireflect.PopulateStructFields(result, s.rootData)
+		ireflect.PopulateMethods(result, s.rootData)
 	}
 
-	s.envCache = result
+	var scopes []*scope
+	for sc := s.top; sc != nil; sc = sc.parent {
+		scopes = append(scopes, sc)
+	}
+	// Iterate from the root to the top, with top overriding bottom
+	for _, sc := range slices.Backward(scopes) {
+		maps.Copy(result, sc.vars)
+	}
 	return result
 }
 
 // ForEach iterates over a collection at the given expr and calls fn(index,value).
-// Supports slices/arrays and map[string]any (iteration order for maps is unspecified).
+// Supports slices, arrays, maps, integers, receive channels and iter.Seq and
+// iter.Seq2 values, see iterate; other values are a no-op. Maps are iterated
+// in key order.
 // If fn returns an error iteration is stopped and the error passed through.
 func (s *Stack) ForEach(expr string, fn func(index int, value any) error) error {
 	v, ok := s.Resolve(expr)
@@ -323,29 +348,226 @@ func (s *Stack) ForEach(expr string, fn func(index int, value any) error) error
 		return nil
 	}
 
-	rv := reflect.ValueOf(v)
+	index := 0
+	return iterate(context.Background(), v, func(_, value any) error {
+		err := fn(index, value)
+		index++
+		return err
+	})
+}
+
+// iterate calls fn with the key and value of each item in collection. The key
+// is the map key for maps and the yielded key for iter.Seq2, and the index of
+// the item otherwise.
+//
+// Supported collections are slices, arrays and maps (in key order, see
+// compareValues), receive channels, iter.Seq and iter.Seq2 values, and integers:
+// n iterates over the values 1 to n, as in Vue. Other values are a no-op.
+// Receiving from a channel stops with ctx.Err() when ctx is done.
+func iterate(ctx context.Context, collection any, fn func(key, value any) error) error {
+	switch c := collection.(type) {
+	case nil:
+		return nil
+	case []any:
+		for i, v := range c {
+			if err := fn(i, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	case iter.Seq[any]:
+		return iterateSeq(c, fn)
+	case iter.Seq[int]:
+		return iterateSeq(c, fn)
+	case iter.Seq2[any, any]:
+		return iterateSeq2(c, fn)
+	case iter.Seq2[string, any]:
+		return iterateSeq2(c, fn)
+	}
+
+	rv := reflect.ValueOf(collection)
 
 	switch rv.Kind() {
 	case reflect.Slice, reflect.Array:
-		// []V
 		for i := 0; i < rv.Len(); i++ {
 			if err := fn(i, rv.Index(i).Interface()); err != nil {
 				return err
 			}
 		}
-		return nil
 	case reflect.Map:
 		keys := rv.MapKeys()
-		for i, key := range keys {
-			if err := fn(i, rv.MapIndex(key).Interface()); err != nil {
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return compareValues(a.Interface(), b.Interface())
+		})
+		for _, key := range keys {
+			if err := fn(key.Interface(), rv.MapIndex(key).Interface()); err != nil {
 				return err
 			}
 		}
-		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		for i := range int(rv.Int()) {
+			if err := fn(i, i+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		for i := range int(rv.Uint()) {
+			if err := fn(i, i+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Chan:
+		if rv.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil
+		}
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: rv},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		}
+		for i := 0; ; i++ {
+			chosen, v, ok := reflect.Select(cases)
+			if chosen == 1 {
+				return ctx.Err()
+			}
+			if !ok {
+				return nil
+			}
+			if err := fn(i, v.Interface()); err != nil {
+				return err
+			}
+		}
+	case reflect.Func:
+		if isSeq(rv.Type()) {
+			return iterateFunc(rv, fn)
+		}
 	}
+	return nil
+}
 
+func iterateSeq[V any](seq iter.Seq[V], fn func(key, value any) error) error {
+	index := 0
+	for v := range seq {
+		if err := fn(index, v); err != nil {
+			return err
+		}
+		index++
+	}
 	return nil
-	// return fmt.Errorf("unsupported collection type: %T, expr: %s", v, expr)
+}
+
+func iterateSeq2[K, V any](seq iter.Seq2[K, V], fn func(key, value any) error) error {
+	for k, v := range seq {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterateFunc iterates over an iter.Seq or iter.Seq2 of any type with reflection.
+func iterateFunc(rv reflect.Value, fn func(key, value any) error) error {
+	yieldType := rv.Type().In(0)
+
+	var err error
+	index := 0
+	yield := reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+		if len(args) == 2 {
+			err = fn(args[0].Interface(), args[1].Interface())
+		} else {
+			err = fn(index, args[0].Interface())
+			index++
+		}
+		return []reflect.Value{reflect.ValueOf(err == nil).Convert(yieldType.Out(0))}
+	})
+	rv.Call([]reflect.Value{yield})
+	return err
+}
+
+// isSeq reports whether t is the type of an iter.Seq or iter.Seq2.
+func isSeq(t reflect.Type) bool {
+	if t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 0 {
+		return false
+	}
+	yield := t.In(0)
+	return yield.Kind() == reflect.Func && (yield.NumIn() == 1 || yield.NumIn() == 2) &&
+		yield.NumOut() == 1 && yield.Out(0).Kind() == reflect.Bool
+}
+
+// compareValues orders values for sorted iteration: nil first, then numbers
+// and booleans by value, strings lexically and other values by their
+// formatted form. Values of different kinds are ordered by their formatted form.
+func compareValues(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		}
+		return 1
+	}
+
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b)
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0
+			case b:
+				return -1
+			}
+			return 1
+		}
+	}
+
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y)
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// toFloat returns the value of a number as a float64.
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// isCollection reports whether value can be iterated by v-for, see iterate.
+func isCollection(value any) bool {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Chan:
+		return t.ChanDir()&reflect.RecvDir != 0
+	case reflect.Func:
+		return isSeq(t)
+	}
+	return false
 }
 
 // Helpers
@@ -2,6 +2,9 @@ package vuego_test
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/titpetric/vuego"
@@ -80,6 +83,49 @@ func TestStack_Set(t *testing.T) {
 	assert.Equal(t, "updated", val)
 }
 
+func TestStack_Copy(t *testing.T) {
+	s := vuego.NewStack(map[string]any{"root": "value"})
+	s.Push(map[string]any{"scope": "inner"})
+
+	c := s.Copy()
+	c.Set("scope", "copy")
+	c.Pop()
+	c.Set("root", "copy")
+
+	val, _ := s.Lookup("scope")
+	assert.Equal(t, "inner", val)
+	val, _ = s.Lookup("root")
+	assert.Equal(t, "value", val)
+
+	val, _ = c.Lookup("root")
+	assert.Equal(t, "copy", val)
+	_, ok := c.Lookup("scope")
+	assert.False(t, ok)
+}
+
+func TestStack_PopKeepsPushedMap(t *testing.T) {
+	vars := map[string]any{"key": "value"}
+
+	s := vuego.NewStack(nil)
+	s.Push(vars)
+	s.Pop()
+
+	assert.Equal(t, map[string]any{"key": "value"}, vars)
+}
+
+func TestStack_EnvMap(t *testing.T) {
+	s := vuego.NewStackWithData(map[string]any{"name": "root", "level": 0}, testUser{FirstName: "Ada"})
+	s.Push(nil)
+	s.Set("level", 1)
+	s.Push(nil)
+	s.Set("level", 2)
+
+	env := s.EnvMap()
+	assert.Equal(t, 2, env["level"])
+	assert.Equal(t, "root", env["name"])
+	assert.Equal(t, "Ada", env["first_name"])
+}
+
 func TestStack_Lookup(t *testing.T) {
 	t.Run("finds in top scope", func(t *testing.T) {
 		s := vuego.NewStack(map[string]any{"base": "bottom"})
@@ -709,6 +755,19 @@ func TestStack_ForEach(t *testing.T) {
 		assert.Equal(t, []int{0, 1, 2}, indices)
 	})
 
+	t.Run("iterates maps in key order", func(t *testing.T) {
+		s := vuego.NewStack(map[string]any{
+			"obj": map[string]any{"c": 3, "a": 1, "b": 2},
+		})
+		var values []any
+		err := s.ForEach("obj", func(i int, v any) error {
+			values = append(values, v)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []any{1, 2, 3}, values)
+	})
+
 	t.Run("error stops iteration", func(t *testing.T) {
 		s := vuego.NewStack(map[string]any{"items": []any{1, 2, 3}})
 		count := 0
@@ -804,8 +863,22 @@ func TestStack_ForEach(t *testing.T) {
 		assert.Equal(t, 0, callCount)
 	})
 
-	t.Run("numeric type is no-op", func(t *testing.T) {
-		s := vuego.NewStack(map[string]any{"count": 42})
+	t.Run("integer counts from 1", func(t *testing.T) {
+		s := vuego.NewStack(map[string]any{"count": 3})
+		var indices []int
+		var values []any
+		err := s.ForEach("count", func(i int, v any) error {
+			indices = append(indices, i)
+			values = append(values, v)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, indices)
+		assert.Equal(t, []any{1, 2, 3}, values)
+	})
+
+	t.Run("float type is no-op", func(t *testing.T) {
+		s := vuego.NewStack(map[string]any{"count": 4.2})
 		callCount := 0
 		err := s.ForEach("count", func(i int, v any) error {
 			callCount++
@@ -815,6 +888,43 @@ func TestStack_ForEach(t *testing.T) {
 		assert.Equal(t, 0, callCount)
 	})
 
+	t.Run("iter.Seq", func(t *testing.T) {
+		s := vuego.NewStack(map[string]any{"seq": slices.Values([]string{"a", "b"})})
+		var results []string
+		err := s.ForEach("seq", func(i int, v any) error {
+			results = append(results, fmt.Sprint(i, v))
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"0a", "1b"}, results)
+	})
+
+	t.Run("iter.Seq2 passes values", func(t *testing.T) {
+		s := vuego.NewStack(map[string]any{"seq": maps.All(map[string]int{"a": 1})})
+		var results []string
+		err := s.ForEach("seq", func(i int, v any) error {
+			results = append(results, fmt.Sprint(i, v))
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"0 1"}, results)
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+		close(ch)
+		s := vuego.NewStack(map[string]any{"ch": (<-chan int)(ch)})
+		sum := 0
+		err := s.ForEach("ch", func(i int, v any) error {
+			sum += v.(int)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, sum)
+	})
+
 	t.Run("empty []any", func(t *testing.T) {
 		s := vuego.NewStack(map[string]any{"items": []any{}})
 		count := 0
@@ -838,6 +948,49 @@ func TestStack_ForEach(t *testing.T) {
 	})
 }
 
+// BenchmarkStack_NestedLoops pushes a scope per item of two nested loops and
+// evaluates an expression in the inner scope, like v-for does.
+func BenchmarkStack_NestedLoops(b *testing.B) {
+	eval := vuego.NewExprEvaluator()
+	root := map[string]any{"title": "grid", "limit": 50}
+	for i := range 20 {
+		root[fmt.Sprintf("var%d", i)] = i
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		s := vuego.NewStack(root)
+		for row := range 10 {
+			s.Push(nil)
+			s.Set("row", row)
+			for col := range 10 {
+				s.Push(nil)
+				s.Set("col", col)
+				if _, err := eval.Eval("row * 10 + col > limit", s); err != nil {
+					b.Fatal(err)
+				}
+				s.Pop()
+			}
+			s.Pop()
+		}
+	}
+}
+
+func BenchmarkStack_Lookup(b *testing.B) {
+	s := vuego.NewStack(map[string]any{"root": "value"})
+	for i := range 5 {
+		s.Push(nil)
+		s.Set(fmt.Sprintf("var%d", i), i)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, ok := s.Lookup("root"); !ok {
+			b.Fatal("root not found")
+		}
+	}
+}
+
 // Test helper structs for struct resolution tests
 
 type testConfig struct {
@@ -0,0 +1,227 @@
+package vuego
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr/ast"
+
+	"github.com/titpetric/vuego/internal/helpers"
+	ireflect "github.com/titpetric/vuego/internal/reflect"
+)
+
+// StrictError is returned in strict mode when a template references an undefined
+// variable or function, or iterates over a value that is not a collection.
+type StrictError struct {
+	// Chain is the template inclusion chain, as formatted by VueContext.FormatTemplateChain.
+	Chain string
+	// Expression is the offending template expression.
+	Expression string
+	// Reason describes what is undefined or invalid.
+	Reason string
+}
+
+// Error returns the template chain, reason and offending expression.
+func (e *StrictError) Error() string {
+	return fmt.Sprintf("in %s: %s in expression '%s'", e.Chain, e.Reason, e.Expression)
+}
+
+// WithStrict returns a LoadOption that enables strict mode.
+// In strict mode, undefined variables, unknown filters and v-for over values
+// that are not collections fail the render with a *StrictError.
+func WithStrict() LoadOption {
+	return func(vue *Vue) {
+		vue.strict = true
+	}
+}
+
+// evalExpr evaluates an expr expression against the scope of ctx, checking
+// its variable references first in strict mode.
+func (v *Vue) evalExpr(ctx VueContext, expression string) (any, error) {
+	if err := v.checkExpr(ctx, expression); err != nil {
+		return nil, err
+	}
+	if v.limits == nil || v.limits.MaxExprTime <= 0 {
+		return v.exprEval.Eval(expression, ctx.exprEnv())
+	}
+
+	exprCtx, cancel := v.withExprDeadline(ctx)
+	defer cancel()
+
+	start := time.Now()
+	val, err := v.exprEval.Eval(expression, exprCtx.exprEnv())
+	if limitErr := v.timeExpr(ctx, expression, start); limitErr != nil {
+		return nil, limitErr
+	}
+	return val, err
+}
+
+// isStrictError reports whether err is, or wraps, a *StrictError.
+// Evaluation fallbacks use it to pass strict mode failures through.
+func isStrictError(err error) bool {
+	var strictErr *StrictError
+	return errors.As(err, &strictErr)
+}
+
+func newStrictError(ctx VueContext, expression, format string, args ...any) *StrictError {
+	return &StrictError{
+		Chain:      ctx.FormatTemplateChain(),
+		Expression: expression,
+		Reason:     fmt.Sprintf(format, args...),
+	}
+}
+
+// checkPath returns a StrictError if a simple variable path like `user.name`
+// or `items[0]` is not defined in the current scope. Values that are defined
+// as nil are allowed. Expressions that do not start with an identifier are skipped.
+func (v *Vue) checkPath(ctx VueContext, path string) error {
+	if !v.strict || path == "" || !helpers.IsIdentifierChar(rune(path[0]), true) {
+		return nil
+	}
+	// Only plain paths are checked, expressions are left to checkExpr
+	if strings.ContainsAny(path, " \t\r\n()+-*/%<>=!&|?:,") {
+		return nil
+	}
+	switch path {
+	case "true", "false", "nil", "null":
+		return nil
+	}
+
+	parts := getCachedPath(path)
+	if len(parts) == 0 {
+		return nil
+	}
+
+	cur, ok := ctx.stack.Lookup(parts[0])
+	if !ok {
+		return newStrictError(ctx, path, "undefined variable '%s'", parts[0])
+	}
+	for i, part := range parts[1:] {
+		if cur, ok = lookupStep(cur, part); !ok {
+			return newStrictError(ctx, path, "undefined variable '%s'", joinPath(parts[:i+2]))
+		}
+	}
+	return nil
+}
+
+// checkExpr returns a StrictError if an expr expression references an
+// undefined variable or function. Builtins, closures and let-declared
+// variables are skipped, as are optional chains like `user?.name`.
+func (v *Vue) checkExpr(ctx VueContext, expression string) error {
+	if !v.strict {
+		return nil
+	}
+
+	refs, err := v.exprEval.references(expression)
+	if err != nil {
+		// Compile errors are reported by evaluation
+		return nil
+	}
+
+	for _, path := range refs {
+		cur, ok := ctx.stack.Lookup(path[0])
+		if !ok {
+			return newStrictError(ctx, expression, "undefined variable '%s'", path[0])
+		}
+		for i, part := range path[1:] {
+			if cur, ok = lookupStep(cur, part); !ok {
+				return newStrictError(ctx, expression, "undefined variable '%s'", joinPath(path[:i+2]))
+			}
+		}
+	}
+	return nil
+}
+
+// checkCollection returns a StrictError if value, evaluated from expression,
+// can't be iterated by v-for.
+func (v *Vue) checkCollection(ctx VueContext, expression string, value any) error {
+	if !v.strict || value == nil || isCollection(value) {
+		// Defined as nil, iterates as empty
+		return nil
+	}
+	return newStrictError(ctx, "v-for="+expression, "cannot iterate over %T", value)
+}
+
+// lookupStep descends into cur by a single path segment. It reports false
+// only when cur is a container that doesn't hold the key; nil values and
+// scalars can't be checked further and are reported as defined.
+func lookupStep(cur any, part string) (any, bool) {
+	if !ireflect.CanDescend(cur) {
+		return nil, true
+	}
+	return ireflect.ResolveValue(cur, part)
+}
+
+func joinPath(parts []string) string {
+	result := parts[0]
+	for _, part := range parts[1:] {
+		result += "." + part
+	}
+	return result
+}
+
+// exprReferences returns the variable paths an expression reads from its
+// environment, e.g. `user.name == x` yields [user name], [user] and [x].
+func exprReferences(node ast.Node) [][]string {
+	var (
+		refs     [][]string
+		declared = map[string]bool{}
+	)
+
+	visitor := &refVisitor{visit: func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.VariableDeclaratorNode:
+			declared[n.Name] = true
+		case *ast.IdentifierNode:
+			refs = append(refs, []string{n.Value})
+		case *ast.MemberNode:
+			if path, ok := memberPath(n); ok {
+				refs = append(refs, path)
+			}
+		}
+	}}
+	ast.Walk(&node, visitor)
+
+	result := refs[:0]
+	for _, path := range refs {
+		if declared[path[0]] || path[0] == "$env" {
+			continue
+		}
+		result = append(result, path)
+	}
+	return result
+}
+
+// memberPath returns the path of a member chain with constant property names,
+// like `user.address.city`. Optional chains and method calls are not paths.
+func memberPath(n *ast.MemberNode) ([]string, bool) {
+	if n.Optional || n.Method {
+		return nil, false
+	}
+	prop, ok := n.Property.(*ast.StringNode)
+	if !ok {
+		return nil, false
+	}
+
+	switch parent := n.Node.(type) {
+	case *ast.IdentifierNode:
+		return []string{parent.Value, prop.Value}, true
+	case *ast.MemberNode:
+		path, ok := memberPath(parent)
+		if !ok {
+			return nil, false
+		}
+		return append(path, prop.Value), true
+	}
+	return nil, false
+}
+
+type refVisitor struct {
+	visit func(ast.Node)
+}
+
+func (r *refVisitor) Visit(node *ast.Node) {
+	r.visit(*node)
+}
@@ -0,0 +1,84 @@
+package vuego_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestStrict(t *testing.T) {
+	data := map[string]any{
+		"user":  map[string]any{"name": "Ana", "nickname": nil},
+		"items": []string{"a", "b"},
+		"count": 3,
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expr     string
+		// lenient is false for templates that already fail without strict mode
+		lenient bool
+	}{
+		{"undefined variable", `<p>{{ missing }}</p>`, "missing", true},
+		{"undefined member", `<p>{{ user.nmae }}</p>`, "user.nmae", true},
+		{"undefined in expression", `<p>{{ user.nmae == "Ana" ? "yes" : "no" }}</p>`, `user.nmae == "Ana" ? "yes" : "no"`, true},
+		{"undefined filter", `<p>{{ user.name | shout }}</p>`, "shout", false},
+		{"undefined in v-if", `<p v-if="isAdmin">admin</p>`, "isAdmin", true},
+		{"undefined in binding", `<a :href="link">x</a>`, "link", true},
+		{"undefined in class object", `<p :class="{a: missing}">x</p>`, "missing", true},
+		{"undefined in style object", `<p :style="{color: missing}">x</p>`, "missing", true},
+		{"v-for over scalar", `<p v-for="i in user.name">{{ i }}</p>`, "v-for=user.name", true},
+		{"v-for over undefined", `<p v-for="i in rows">{{ i }}</p>`, "rows", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"page.vuego":    &fstest.MapFile{Data: []byte(`<template include="partial.vuego"></template>`)},
+				"partial.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+
+			var buf bytes.Buffer
+			if tc.lenient {
+				err := vuego.NewVue(templateFS).Render(t.Context(), &buf, "page.vuego", data)
+				assert.NoError(t, err)
+			}
+
+			tpl := vuego.NewFS(templateFS, vuego.WithStrict())
+			err := tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf)
+			assert.Error(t, err)
+
+			var strictErr *vuego.StrictError
+			assert.True(t, errors.As(err, &strictErr), "expected StrictError, got %v", err)
+			assert.Equal(t, "page.vuego -> partial.vuego", strictErr.Chain)
+			assert.Equal(t, tc.expr, strictErr.Expression)
+		})
+	}
+}
+
+func TestStrict_DefinedValues(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<p v-if="user.nickname == nil">{{ user.name | upper }}</p>
+<p v-for="(i, item) in items">{{ item }}</p>
+<p :class="{active: true}">{{ len(items) + 1 }}</p>
+<p v-if="user?.missing == nil">{{ filter(items, # != "a")[0] }}</p>`)},
+	}
+
+	data := map[string]any{
+		"user":  map[string]any{"name": "Ana", "nickname": nil},
+		"items": []string{"a", "b"},
+	}
+
+	var buf bytes.Buffer
+	tpl := vuego.NewFS(templateFS, vuego.WithStrict())
+	err := tpl.Load("page.vuego").Fill(data).Render(t.Context(), &buf)
+	assert.NoError(t, err)
+
+	want := `<p>ANA</p><p>a</p><p>b</p><p class="active">3</p><p>b</p>`
+	assert.EqualHTML(t, []byte(want), buf.Bytes(), nil, nil)
+}
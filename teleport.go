@@ -0,0 +1,159 @@
+package vuego
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego/internal/helpers"
+)
+
+// teleport is content moved to another part of the document.
+type teleport struct {
+	to    string
+	key   string
+	nodes []*html.Node
+}
+
+// teleporter collects `<teleport to="...">` elements and elements with a
+// v-teleport attribute from the rendered DOM.
+type teleporter struct {
+	teleports []teleport
+	err       error
+}
+
+// teleportNodes moves teleported content to its target in the final DOM, after
+// the layout chain is applied. The children of `<teleport to="head">` and
+// elements with `v-teleport="head"` are appended to the first element matching
+// the target, in document order. A target is a tag name, `#id` or `.class`.
+// Content with a key attribute is only moved once per key, later duplicates
+// are removed. A disabled teleport renders its children in place.
+//
+// A fragment or partial may not contain the target, so content for a missing
+// target is appended to the end of the document. In strict mode, a missing
+// target fails the render with a *StrictError.
+func (v *Vue) teleportNodes(ctx VueContext, nodes []*html.Node) ([]*html.Node, error) {
+	if !slices.ContainsFunc(nodes, hasTeleport) {
+		return nodes, nil
+	}
+
+	t := &teleporter{}
+	nodes = t.collect(nodes)
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	seen := make(map[string]bool)
+	for _, tp := range t.teleports {
+		if tp.key != "" {
+			if seen[tp.key] {
+				continue
+			}
+			seen[tp.key] = true
+		}
+
+		target := findTarget(nodes, tp.to)
+		if target == nil {
+			if v.strict {
+				return nil, newStrictError(ctx, tp.to, "teleport target '%s' not found", tp.to)
+			}
+			nodes = append(nodes, tp.nodes...)
+			continue
+		}
+		helpers.SetChildren(target, append(helpers.Children(target), tp.nodes...))
+	}
+	return nodes, nil
+}
+
+// collect removes teleported content from nodes and returns the remaining nodes.
+func (t *teleporter) collect(nodes []*html.Node) []*html.Node {
+	var result []*html.Node
+	for _, node := range nodes {
+		if node.Type != html.ElementNode {
+			result = append(result, node)
+			continue
+		}
+
+		if node.Data == "teleport" {
+			children := t.collect(helpers.Children(node))
+			if helpers.HasAttr(node, "disabled") && helpers.GetAttr(node, "disabled") != "false" {
+				result = append(result, children...)
+				continue
+			}
+			t.add(node, helpers.GetAttr(node, "to"), children)
+			continue
+		}
+
+		if children := helpers.Children(node); len(children) > 0 {
+			helpers.SetChildren(node, t.collect(children))
+		}
+
+		if helpers.HasAttr(node, "v-teleport") {
+			to := helpers.GetAttr(node, "v-teleport")
+			helpers.RemoveAttr(node, "v-teleport")
+			t.add(node, to, []*html.Node{node})
+			helpers.RemoveAttr(node, "key")
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+func (t *teleporter) add(node *html.Node, to string, nodes []*html.Node) {
+	to = strings.TrimSpace(to)
+	if to == "" {
+		if t.err == nil {
+			t.err = fmt.Errorf("teleport requires a target, e.g. to=\"head\"")
+		}
+		return
+	}
+	t.teleports = append(t.teleports, teleport{
+		to:    to,
+		key:   helpers.GetAttr(node, "key"),
+		nodes: nodes,
+	})
+}
+
+func hasTeleport(node *html.Node) bool {
+	if node.Type != html.ElementNode {
+		return false
+	}
+	if node.Data == "teleport" || helpers.HasAttr(node, "v-teleport") {
+		return true
+	}
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if hasTeleport(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// findTarget returns the first element matching a tag name, `#id` or `.class` selector.
+func findTarget(nodes []*html.Node, selector string) *html.Node {
+	for _, node := range nodes {
+		if node.Type != html.ElementNode {
+			continue
+		}
+		if matchTarget(node, selector) {
+			return node
+		}
+		if found := findTarget(helpers.Children(node), selector); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func matchTarget(node *html.Node, selector string) bool {
+	switch selector[0] {
+	case '#':
+		return helpers.GetAttr(node, "id") == selector[1:]
+	case '.':
+		return slices.Contains(strings.Fields(helpers.GetAttr(node, "class")), selector[1:])
+	}
+	return strings.EqualFold(node.Data, selector)
+}
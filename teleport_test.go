@@ -0,0 +1,93 @@
+package vuego_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestTeleport(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "to head and id",
+			template: `<html><head><title>t</title></head><body><div id="modals"></div><main><teleport to="head"><meta name="description" :content="desc"></teleport><teleport to="#modals"><p>modal</p></teleport>text</main></body></html>`,
+			data:     map[string]any{"desc": "About"},
+			want:     `<html><head><title>t</title><meta name="description" content="About"></head><body><div id="modals"><p>modal</p></div><main>text</main></body></html>`,
+		},
+		{
+			name:     "v-teleport and key",
+			template: `<html><head></head><body><link v-teleport="head" key="canonical" rel="canonical" :href="url"><link v-teleport="head" key="canonical" rel="canonical" href="/other"><script v-teleport="body" src="/a.js"></script><p>x</p></body></html>`,
+			data:     map[string]any{"url": "/page"},
+			want:     `<html><head><link rel="canonical" href="/page"></head><body><p>x</p><script src="/a.js"></script></body></html>`,
+		},
+		{
+			name:     "disabled renders in place",
+			template: `<div class="box"><teleport to="head" disabled><b>here</b></teleport></div>`,
+			want:     `<div class="box"><b>here</b></div>`,
+		},
+		{
+			name:     "from components in a loop",
+			template: `<div class="box"><section class="widgets"></section><template v-for="n in items"><teleport to=".widgets" :key="n"><i>{{ n }}</i></teleport></template></div>`,
+			data:     map[string]any{"items": []string{"a", "b", "a"}},
+			want:     `<div class="box"><section class="widgets"><i>a</i><i>b</i></section></div>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"page.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+			tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestTeleport_Layout(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"layouts/base.vuego": &fstest.MapFile{Data: []byte(`<html><head><title>{{ title }}</title></head><body><div v-html="content"></div></body></html>`)},
+		"page.vuego":         &fstest.MapFile{Data: []byte("---\nlayout: base\ntitle: Home\n---\n<teleport to=\"head\"><meta name=\"robots\" content=\"noindex\"></teleport><p>home</p>")},
+	}
+	tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Render(t.Context(), &buf))
+	assert.Equal(t, `<html><head><title>Home</title><meta name="robots" content="noindex"></head><body><div><p>home</p></div></body></html>`, buf.String())
+}
+
+func TestTeleport_MissingTarget(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<div><teleport to="#missing"><p>x</p></teleport></div><span>y</span>`)},
+	}
+
+	t.Run("content is moved to the end", func(t *testing.T) {
+		tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+		var buf bytes.Buffer
+		assert.NoError(t, tpl.Load("page.vuego").Render(t.Context(), &buf))
+		assert.Equal(t, `<div></div><span>y</span><p>x</p>`, buf.String())
+	})
+
+	t.Run("strict mode fails the render", func(t *testing.T) {
+		tpl := vuego.NewFS(templateFS, vuego.WithStrict())
+
+		var buf bytes.Buffer
+		err := tpl.Load("page.vuego").Render(t.Context(), &buf)
+		var strictErr *vuego.StrictError
+		assert.True(t, errors.As(err, &strictErr))
+		assert.Equal(t, "teleport target '#missing' not found", strictErr.Reason)
+	})
+}
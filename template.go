@@ -45,6 +45,15 @@ func WithFuncs(funcMap FuncMap) LoadOption {
 	}
 }
 
+// WithRenderer returns a LoadOption that sets the Renderer used to serialize output.
+// It applies to Render, RenderFragment and the layout chain. The default renderer
+// indents the output; see NewMinifyRenderer and NewFaithfulRenderer for alternatives.
+func WithRenderer(renderer Renderer) LoadOption {
+	return func(vue *Vue) {
+		vue.renderer = renderer
+	}
+}
+
 // Template represents a prepared vuego template.
 // It allows variable assignment and rendering with internal buffering.
 type Template interface {
@@ -89,7 +98,6 @@ type template struct {
 	// filename and error for Template.Load
 	err            error
 	frontMatter    map[string]any
-	templateBytes  []byte
 	filename       string
 	filenameLoaded bool
 }
@@ -290,8 +298,8 @@ func (t *template) SetErr(err error) {
 func (t *template) Load(filename string) Template {
 	tpl := t.new()
 
-	// Load the template with front-matter and raw template bytes
-	tpl.frontMatter, tpl.templateBytes, tpl.err = t.vue.loader.loadFragment(filename)
+	// Load the template front-matter through the shared template cache
+	tpl.frontMatter, _, tpl.err = t.vue.loadCachedWithFrontMatter(filename)
 	tpl.filename = filename
 	tpl.filenameLoaded = true
 
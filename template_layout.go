@@ -10,7 +10,7 @@ import (
 
 	"golang.org/x/net/html"
 
-	"github.com/titpetric/vuego/internal/parser"
+	"github.com/titpetric/vuego/internal/helpers"
 )
 
 // extractSlotsFromDOM extracts named slot definitions from a DOM tree before rendering.
@@ -90,52 +90,105 @@ func (t *template) resolveLayoutPath(layout, currentFile string) string {
 	return "layouts/" + layout + ".vuego"
 }
 
+// layoutContent holds the evaluated nodes of one template in a layout chain.
+// It is passed to the next layout as `content`; `v-html="content"` and a default
+// `<slot>` splice the nodes into the layout, so the chain is serialized only once.
+type layoutContent struct {
+	nodes []*html.Node
+	// ctx is the context of the layout chain, for cancellation and limits.
+	ctx VueContext
+	vue *Vue
+	// err is the first error of String, returned by the layout chain.
+	err error
+}
+
+// String renders the content to HTML with the configured renderer, for
+// layouts that use content as a value. It renders within the context and
+// output limit of the render; as String can't fail, an error is kept in
+// c.err for the layout chain and an empty string is returned.
+func (c *layoutContent) String() string {
+	var sb strings.Builder
+	err := c.ctx.Err()
+	if err == nil {
+		err = c.vue.renderer.Render(c.ctx.Context(), c.vue.limitOutput(c.ctx, &sb), c.nodes)
+	}
+	if err != nil {
+		if c.err == nil {
+			c.err = err
+		}
+		return ""
+	}
+	return sb.String()
+}
+
+// clone returns a deep copy of the content nodes, ready to be linked into an output tree.
+func (c *layoutContent) clone() []*html.Node {
+	result := make([]*html.Node, 0, len(c.nodes))
+	for _, node := range c.nodes {
+		result = append(result, helpers.DeepCloneNode(node))
+	}
+	return result
+}
+
 // layout loads a template, and if the template contains "layout" in the metadata, it will
 // load another template from layouts/%s.vuego; Layouts can be chained so one layout can
 // again trigger another layout, like `blog.vuego -> layouts/post.vuego -> layouts/base.vuego`.
 // If no layout is specified on the first template, defaults to layouts/base.vuego if available.
 // Layout paths are resolved relative to the current template first, then fall back to layouts/.
-// Requires that Load() has been called first.
+// Each template in the chain is evaluated to nodes which are passed to the next layout as
+// `content`. Node processors are created once for the chain and the final DOM is post-processed
+// and serialized once. Requires that Load() has been called first.
 func (t *template) layout(ctx context.Context, w io.Writer) error {
 	if !t.filenameLoaded {
 		return fmt.Errorf("no template loaded; call Load() first")
 	}
 
+	// The data is flattened once for the chain, each template is given a copy of it
 	data := t.stack.EnvMap()
 	filename := t.filename
 	isFirstTemplate := true
 	maxDepth := 100
 	depth := 0
 	var inheritedSlotScope *SlotScope // Slots defined in child templates (as DOM nodes)
+	var content *layoutContent        // Content of the previous template, if any
+
+	// Processors are instantiated once and shared by every template in the chain
+	vueCtx := NewVueContext(ctx, t.filename, &VueContextOptions{
+		Processors: t.vue.nodeProcessors,
+	})
 
-	// Build layout chain and render intermediate templates
+	// Build layout chain and evaluate intermediate templates
 	for {
 		if depth >= maxDepth {
 			return fmt.Errorf("layout chain depth exceeded maximum of %d, possible circular dependency", maxDepth)
 		}
 		depth++
 
-		// Create a fresh buffer for each iteration
-		buf := new(bytes.Buffer)
+		if err := vueCtx.Err(); err != nil {
+			return err
+		}
+
 		tplInterface := t.Load(filename).Fill(data)
 		tpl := tplInterface.(*template)
 
 		// Extract slot definitions from the template DOM before rendering (only for first template)
 		if isFirstTemplate {
-			// Parse the template bytes to get DOM nodes
-			templateNodes, err := parser.ParseTemplateBytes(tpl.templateBytes)
+			_, templateNodes, err := t.vue.loadCachedWithFrontMatter(filename)
 			if err == nil {
 				inheritedSlotScope = extractSlotsFromDOM(templateNodes)
 			}
 		}
 
-		if err := tpl.renderWithoutLayout(ctx, buf); err != nil {
+		nodes, err := t.vue.evaluateFile(vueCtx, filename, tpl.stack.Copy())
+		if err != nil {
 			return err
 		}
+		if content != nil && content.err != nil {
+			return content.err
+		}
 
-		contentHTML := buf.String()
-
-		data["content"] = contentHTML
+		content = &layoutContent{nodes: nodes, ctx: vueCtx, vue: t.vue}
+		data["content"] = content
 		// Pass inherited slots to the layout via the SlotScope so they can be used by <slot> elements
 		if inheritedSlotScope != nil && len(inheritedSlotScope.Slots) > 0 {
 			data["__slotScope__"] = inheritedSlotScope
@@ -152,7 +205,12 @@ func (t *template) layout(ctx context.Context, w io.Writer) error {
 				delete(data, "layout")
 				continue
 			}
-			// No more layouts, render final template
+
+			// No more layouts, post-process and serialize the final DOM
+			buf := new(bytes.Buffer)
+			if err := t.vue.renderResult(vueCtx, buf, nodes); err != nil {
+				return err
+			}
 			_, err := io.Copy(w, buf)
 			return err
 		}
@@ -3,10 +3,13 @@ package vuego_test
 import (
 	"bytes"
 	"embed"
+	"fmt"
 	"io/fs"
 	"testing"
 	"testing/fstest"
 
+	"golang.org/x/net/html"
+
 	"github.com/titpetric/vuego"
 	"github.com/titpetric/vuego/testing/assert"
 )
@@ -236,3 +239,106 @@ layout: circular
 		assert.Contains(t, err.Error(), "layout chain depth exceeded maximum of 100")
 	})
 }
+
+// layoutProcessor records what node processors observe during a layout render.
+type layoutProcessor struct {
+	instances *int
+	calls     int
+	sections  int
+}
+
+func (p *layoutProcessor) New() vuego.NodeProcessor {
+	*p.instances++
+	return &layoutProcessor{instances: p.instances}
+}
+
+func (p *layoutProcessor) PreProcess(nodes []*html.Node) error { return nil }
+
+func (p *layoutProcessor) PostProcess(nodes []*html.Node) error {
+	p.calls++
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "section" {
+			p.sections++
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	if p.calls != 1 || p.sections != 1 {
+		return fmt.Errorf("post-processed %d times, saw %d page sections", p.calls, p.sections)
+	}
+	return nil
+}
+
+func TestRenderLayout_ContentIsDOM(t *testing.T) {
+	inlineFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`---
+layout: post
+---
+<section><p>{{ title }}</p></section>`)},
+		"layouts/post.vuego": &fstest.MapFile{Data: []byte(`---
+layout: base
+---
+<article v-html="content"></article>`)},
+		"layouts/base.vuego": &fstest.MapFile{Data: []byte(`<main><slot></slot></main><footer>{{ content | string | trim }}</footer>`)},
+	}
+
+	instances := 0
+	processor := &layoutProcessor{instances: &instances}
+	renderer := vuego.NewFS(inlineFS, vuego.WithProcessor(processor))
+
+	var buf bytes.Buffer
+	err := renderer.Load("page.vuego").Fill(map[string]any{"title": "Hello"}).Render(t.Context(), &buf)
+	assert.NoError(t, err)
+
+	// The page DOM is spliced through both layouts and post-processed once, by one processor instance.
+	assert.Equal(t, 1, instances)
+	output := buf.String()
+	assert.Contains(t, output, "<main>\n  <article><section>")
+	assert.Contains(t, output, "</section></article>\n</main>")
+
+	// Content used as a value still renders to the HTML string of the wrapped template.
+	assert.Contains(t, output, "<footer>&lt;article&gt;&lt;section&gt;")
+}
+
+func TestRenderLayout_ContentWhitespace(t *testing.T) {
+	t.Run("preformatted content is kept verbatim", func(t *testing.T) {
+		inlineFS := fstest.MapFS{
+			"page.vuego":         &fstest.MapFile{Data: []byte("---\nlayout: code\n---\n  func main() {\n    run()\n  }\n")},
+			"layouts/code.vuego": &fstest.MapFile{Data: []byte(`<pre v-html="content"></pre>`)},
+		}
+
+		var buf bytes.Buffer
+		err := vuego.NewFS(inlineFS).Load("page.vuego").Render(t.Context(), &buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "<pre>  func main() {\n    run()\n  }\n</pre>")
+	})
+
+	t.Run("content as a value uses the configured renderer", func(t *testing.T) {
+		inlineFS := fstest.MapFS{
+			"page.vuego":        &fstest.MapFile{Data: []byte("---\nlayout: raw\n---\n<p>a</p>  <p>b</p>")},
+			"layouts/raw.vuego": &fstest.MapFile{Data: []byte(`<div>{{ content }}</div>`)},
+		}
+
+		var buf bytes.Buffer
+		err := vuego.NewFS(inlineFS, vuego.WithRenderer(vuego.NewFaithfulRenderer())).Load("page.vuego").Render(t.Context(), &buf)
+		assert.NoError(t, err)
+		assert.Equal(t, `<div>&lt;p&gt;a&lt;/p&gt;  &lt;p&gt;b&lt;/p&gt;</div>`, buf.String())
+	})
+}
+
+func TestRenderLayout_ContentValueLimits(t *testing.T) {
+	inlineFS := fstest.MapFS{
+		"page.vuego":        &fstest.MapFile{Data: []byte("---\nlayout: len\n---\n<p>Some page content</p>")},
+		"layouts/len.vuego": &fstest.MapFile{Data: []byte(`<div>{{ content | string | len }}</div>`)},
+	}
+	tpl := vuego.NewFS(inlineFS, vuego.WithLimits(vuego.Limits{MaxOutputBytes: 20}))
+
+	var buf bytes.Buffer
+	err := tpl.Load("page.vuego").Render(t.Context(), &buf)
+	assert.ErrorIs(t, err, vuego.ErrOutputLimit)
+}
@@ -6,9 +6,7 @@ import (
 	"fmt"
 	"io"
 
-	"golang.org/x/net/html"
-
-	"github.com/titpetric/vuego/internal/helpers"
+	"github.com/titpetric/vuego/internal/parser"
 )
 
 // Render processes the loaded template and writes the output to w.
@@ -48,7 +46,7 @@ func (t *template) renderWithoutLayout(ctx context.Context, w io.Writer) error {
 		return err
 	}
 
-	return t.vue.Render(ctx, w, t.filename, t.stack.EnvMap())
+	return t.vue.renderStack(ctx, w, t.filename, t.stack.Copy())
 }
 
 // RenderFile processes the template file and writes the output to w.
@@ -81,18 +79,26 @@ func (t *template) RenderReader(ctx context.Context, w io.Writer, r io.Reader) e
 		return err
 	}
 
-	// Parse the template from reader as a fragment
-	body := helpers.GetBodyNode()
-	dom, err := html.ParseFragment(r, body)
+	templateBytes, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading template: %w", err)
+	}
+
+	// Parse the template as a fragment
+	dom, positions, err := parser.ParseTemplateBytesWithPositions(templateBytes, 0)
 	if err != nil {
 		return fmt.Errorf("error parsing template: %w", err)
 	}
 
+	if err := t.vue.compile(dom); err != nil {
+		return err
+	}
+
 	// Create VueContext with filename from loaded template
 	vueCtx := NewVueContext(ctx, t.filename, &VueContextOptions{
 		Stack:      t.stack.Copy(),
 		Processors: t.vue.nodeProcessors,
-	})
+	}).withCompiled(newCompiledTemplate(t.filename, dom, positions))
 
 	// Buffer the output to ensure w is unmodified on error
 	buf := &bytes.Buffer{}
@@ -3,8 +3,11 @@ package vuego_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"os"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/titpetric/vuego"
 	"github.com/titpetric/vuego/testing/assert"
@@ -118,6 +121,52 @@ func TestTemplate_RenderCancelledContext(t *testing.T) {
 	assert.Equal(t, context.Canceled, err)
 }
 
+func TestTemplate_RenderCancelledDuringEvaluation(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"list.vuego":         &fstest.MapFile{Data: []byte(`<ul><li v-for="item in items">{{ tick() }}</li></ul>`)},
+		"page.vuego":         &fstest.MapFile{Data: []byte("---\nlayout: base\n---\n<template include=\"list.vuego\"></template>")},
+		"layouts/base.vuego": &fstest.MapFile{Data: []byte(`<main v-html="content"></main>`)},
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var ticks int
+	tmpl := vuego.NewFS(templateFS, vuego.WithFuncs(vuego.FuncMap{"tick": func() int {
+		ticks++
+		if ticks == 1000 {
+			cancel()
+		}
+		return ticks
+	}}))
+
+	buf := &bytes.Buffer{}
+	err := tmpl.Load("page.vuego").Fill(map[string]any{"items": make([]int, 1_000_000)}).Render(ctx, buf)
+	assert.ErrorIs(t, err, context.Canceled)
+	var renderErr *vuego.RenderError
+	assert.True(t, errors.As(err, &renderErr))
+	assert.Equal(t, []string{"page.vuego", "list.vuego"}, renderErr.Chain)
+	assert.Equal(t, 1000, ticks)
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestTemplate_RenderDeadlineExceeded(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<ul><li v-for="item in items">{{ wait() }}</li></ul>`)},
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	tmpl := vuego.NewFS(templateFS, vuego.WithFuncs(vuego.FuncMap{"wait": func(ctx context.Context) string {
+		<-ctx.Done()
+		return ""
+	}}))
+
+	err := tmpl.Load("page.vuego").Fill(map[string]any{"items": make([]int, 1_000_000)}).Render(ctx, &bytes.Buffer{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestTemplate_FrontMatterVariables(t *testing.T) {
 	templateFS := os.DirFS("testdata/fixtures")
 
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 	"testing/fstest"
@@ -118,6 +119,64 @@ func BenchmarkVue_Render_Detailed(b *testing.B) {
 	b.ReportMetric(float64(avgTotal.Microseconds()), "µs/op")
 }
 
+func getGridData() map[string]any {
+	rows := make([]map[string]any, 30)
+	for i := range rows {
+		cells := make([]map[string]any, 30)
+		for j := range cells {
+			cells[j] = map[string]any{"value": i * j, "label": "cell"}
+		}
+		rows[i] = map[string]any{"id": i, "cells": cells}
+	}
+	data := map[string]any{
+		"title":     "Grid",
+		"threshold": 100,
+		"rows":      rows,
+		"site":      map[string]any{"name": "VueGo", "theme": "dark"},
+	}
+	// Pages usually carry site config and front matter at the root
+	for i := range 20 {
+		data["setting"+strconv.Itoa(i)] = i
+	}
+	return data
+}
+
+func getGridTemplate() []byte {
+	return []byte(`<table :class="site.theme">
+  <caption>{{ title }}</caption>
+  <tr v-for="row in rows" :data-row="row.id">
+    <td v-for="cell in row.cells" :class="cell.value > threshold ? 'high' : 'low'">
+      <span v-if="cell.value % 2 == 0">{{ cell.label }} {{ row.id * 100 + cell.value }}</span>
+      <em v-else>{{ site.name }}</em>
+    </td>
+  </tr>
+</table>`)
+}
+
+// BenchmarkVue_Render_NestedLoops renders a grid of nested loops that
+// evaluate expressions in every iteration.
+func BenchmarkVue_Render_NestedLoops(b *testing.B) {
+	fs := fstest.MapFS{
+		"grid.vuego": &fstest.MapFile{
+			Data: getGridTemplate(),
+		},
+	}
+
+	vue := vuego.NewVue(fs)
+	data := getGridData()
+
+	var buf strings.Builder
+	assert.NoError(b, vue.Render(b.Context(), &buf, "grid.vuego", data))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var buf strings.Builder
+		if err := vue.Render(b.Context(), &buf, "grid.vuego", data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestDetailedRenderTiming(t *testing.T) {
 	fs := fstest.MapFS{
 		"blog.vuego": &fstest.MapFile{
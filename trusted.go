@@ -0,0 +1,27 @@
+package vuego
+
+// Trusted value types mark content that is known to be safe in a specific
+// context. They mirror the types of html/template: a value of a trusted type is
+// written as is in its context, and escaped like any other value elsewhere.
+//
+// Use them for content from a trusted source, like markup built by Go code
+// and returned from a FuncMap function. Converting user input to a trusted
+// type bypasses escaping and opens the page to XSS.
+type (
+	// HTML is a trusted HTML fragment, written as is in element content
+	// and srcdoc attributes.
+	HTML string
+
+	// URL is a trusted URL. It is written to URL attributes like href
+	// without filtering its scheme, so it may be a javascript: URL.
+	URL string
+
+	// JS is a trusted JavaScript expression, written as is in <script>
+	// content and on* event handler attributes. Inside a JS string literal
+	// it is escaped like any other value.
+	JS string
+
+	// CSS is trusted CSS, written as is in <style> content and style
+	// attributes. Inside a CSS string it is escaped like any other value.
+	CSS string
+)
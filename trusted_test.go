@@ -0,0 +1,97 @@
+package vuego_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+func TestTrustedTypes(t *testing.T) {
+	funcs := vuego.FuncMap{
+		"badge": func(label string) vuego.HTML {
+			return vuego.HTML(`<span class="badge">` + label + `</span>`)
+		},
+		"track": func(id string) vuego.JS {
+			return vuego.JS(`track("` + id + `")`)
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     string
+	}{
+		{
+			name:     "html from a func is written as is",
+			template: `<p>New {{ badge("hot") }}!</p>`,
+			want:     `<p>New <span class="badge">hot</span>!</p>`,
+		},
+		{
+			name:     "html from data is written as is",
+			template: `<div>{{ body }}</div>`,
+			data:     map[string]any{"body": vuego.HTML("<b>bold</b> &amp; brave")},
+			want:     `<div><b>bold</b> &amp; brave</div>`,
+		},
+		{
+			name:     "html is escaped in attributes",
+			template: `<p :title="body" data-x="{{ body }}"></p>`,
+			data:     map[string]any{"body": vuego.HTML("<b>x</b>")},
+			want:     `<p title="&lt;b&gt;x&lt;/b&gt;" data-x="&lt;b&gt;x&lt;/b&gt;"></p>`,
+		},
+		{
+			name:     "strings that look escaped are escaped",
+			template: `<p>{{ name }}</p><p>Tom &amp; Jerry</p>`,
+			data:     map[string]any{"name": "Tom &amp; Jerry; <script>"},
+			want:     `<p>Tom &amp;amp; Jerry; &lt;script&gt;</p><p>Tom &amp; Jerry</p>`,
+		},
+		{
+			name:     "url skips the scheme filter",
+			template: `<a :href="trusted">a</a><a :href="untrusted">b</a><a href="{{ trusted }}">c</a>`,
+			data:     map[string]any{"trusted": vuego.URL("javascript:void(0)"), "untrusted": "javascript:void(0)"},
+			want:     `<a href="javascript:void(0)">a</a><a href="#ZgotmplZ">b</a><a href="javascript:void(0)">c</a>`,
+		},
+		{
+			name:     "js in scripts and event handlers",
+			template: `<script>{{ track("home") }}; var s = "{{ track("x") }}";</script><button :onclick="track('btn')" onfocus="{{ code }}"></button>`,
+			data:     map[string]any{"code": vuego.JS("focus()")},
+			want:     `<script>track("home"); var s = "track(\"x\")";</script><button onclick="track(&#34;btn&#34;)" onfocus="focus()"></button>`,
+		},
+		{
+			name:     "css in styles",
+			template: `<style>p { {{ rules }} }</style><p :style="rules"></p><p>{{ rules }}</p>`,
+			data:     map[string]any{"rules": vuego.CSS("color: red; font: 1em/2 serif")},
+			want:     `<style>p { color: red; font: 1em/2 serif }</style><p style="color: red; font: 1em/2 serif"></p><p>color: red; font: 1em/2 serif</p>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"page.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+			tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()), vuego.WithFuncs(funcs))
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestTrustedTypes_File(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<style>{{ file("a.css") }}</style><script>{{ file("a.js") }} var t = {{ file("a.txt") }};</script>`)},
+		"a.css":      &fstest.MapFile{Data: []byte(`p > a { color: red }`)},
+		"a.js":       &fstest.MapFile{Data: []byte(`if (a < b) go();`)},
+		"a.txt":      &fstest.MapFile{Data: []byte(`</script>`)},
+	}
+	tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Render(t.Context(), &buf))
+	assert.Equal(t, `<style>p > a { color: red }</style><script>if (a < b) go(); var t = "\u003c/script\u003e";</script>`, buf.String())
+}
@@ -0,0 +1,172 @@
+package vuego_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+type typedAddress struct {
+	City string `json:"city"`
+}
+
+type typedUser struct {
+	First   string       `json:"first"`
+	Last    string       `json:"last"`
+	Joined  time.Time    `json:"joined"`
+	Address typedAddress `json:"address"`
+}
+
+func (u typedUser) FullName() string {
+	return u.First + " " + u.Last
+}
+
+func (u *typedUser) Initials() string {
+	return u.First[:1] + u.Last[:1]
+}
+
+type typedStatus int
+
+func (s typedStatus) String() string {
+	return [...]string{"pending", "paid"}[s]
+}
+
+type typedOrder struct {
+	ID     int
+	Status typedStatus
+}
+
+func (o *typedOrder) IsPaid() bool {
+	return o.Status == 1
+}
+
+// typedCode implements encoding.TextMarshaler, but not fmt.Stringer.
+type typedCode struct {
+	Prefix string
+	Number int
+}
+
+func (c typedCode) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(c.Prefix) + "-" + time.Duration(c.Number).String()), nil
+}
+
+type typedPage struct {
+	Title string
+	User  *typedUser
+}
+
+func (p typedPage) Heading() string {
+	return strings.ToUpper(p.Title)
+}
+
+func (p *typedPage) HasUser() bool {
+	return p.User != nil
+}
+
+func TestVue_TypedValues(t *testing.T) {
+	user := typedUser{
+		First:   "Ada",
+		Last:    "Lovelace",
+		Joined:  time.Date(1815, time.December, 10, 0, 0, 0, 0, time.UTC),
+		Address: typedAddress{City: "London"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     any
+		want     string
+	}{
+		{
+			name:     "method call in interpolation",
+			template: `<p>{{ user.FullName() }}</p>`,
+			data:     map[string]any{"user": user},
+			want:     `<p>Ada Lovelace</p>`,
+		},
+		{
+			name:     "method with a pointer receiver",
+			template: `<p>{{ user.Initials() }}</p>`,
+			data:     map[string]any{"user": user},
+			want:     `<p>AL</p>`,
+		},
+		{
+			name:     "method call piped to a filter",
+			template: `<p>{{ user.FullName() | upper }}</p>`,
+			data:     map[string]any{"user": user},
+			want:     `<p>ADA LOVELACE</p>`,
+		},
+		{
+			name:     "method call in v-if",
+			template: `<p v-if="order.IsPaid()">paid</p><p v-else>unpaid</p>`,
+			data:     map[string]any{"order": typedOrder{ID: 1, Status: 1}},
+			want:     `<p>paid</p>`,
+		},
+		{
+			name:     "method call in a binding",
+			template: `<a :title="user.FullName()">x</a>`,
+			data:     map[string]any{"user": &user},
+			want:     `<a title="Ada Lovelace">x</a>`,
+		},
+		{
+			name:     "nested struct fields by JSON tag in expressions",
+			template: `<p>{{ user.address.city == "London" ? "yes" : "no" }}</p>`,
+			data:     map[string]any{"user": user},
+			want:     `<p>yes</p>`,
+		},
+		{
+			name:     "time.Time keeps its methods",
+			template: `<p>{{ user.Joined.Year() }}</p>`,
+			data:     map[string]any{"user": user},
+			want:     `<p>1815</p>`,
+		},
+		{
+			name:     "struct root with fields and methods",
+			template: `<h1>{{ Heading() }}</h1><p v-if="HasUser()">{{ User.FullName() }}</p>`,
+			data:     typedPage{Title: "Users", User: &user},
+			want:     `<h1>USERS</h1><p>Ada Lovelace</p>`,
+		},
+		{
+			name:     "values are printed with fmt.Stringer",
+			template: `<p :class="order.Status">{{ order.Status }}</p>`,
+			data:     map[string]any{"order": typedOrder{ID: 1}},
+			want:     `<p class="pending">pending</p>`,
+		},
+		{
+			name:     "values are printed with encoding.TextMarshaler",
+			template: `<p>{{ code }}</p>`,
+			data:     map[string]any{"code": typedCode{Prefix: "ord", Number: 5}},
+			want:     `<p>ORD-5ns</p>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templateFS := fstest.MapFS{
+				"page.vuego": &fstest.MapFile{Data: []byte(tc.template)},
+			}
+			tpl := vuego.NewFS(templateFS, vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Load("page.vuego").Fill(tc.data).Render(t.Context(), &buf))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestVue_TypedValuesStrict(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"page.vuego": &fstest.MapFile{Data: []byte(`<p v-if="HasUser() && User.address.city != ''">{{ User.FullName() }}, {{ User.address.city }}</p>`)},
+	}
+	tpl := vuego.NewFS(templateFS, vuego.WithStrict(), vuego.WithRenderer(vuego.NewFaithfulRenderer()))
+
+	user := &typedUser{First: "Ada", Last: "Lovelace", Address: typedAddress{City: "London"}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Load("page.vuego").Fill(typedPage{User: user}).Render(t.Context(), &buf))
+	assert.Equal(t, `<p>Ada Lovelace, London</p>`, buf.String())
+}
@@ -5,22 +5,13 @@ import (
 	"io"
 	"io/fs"
 	"sync"
-	"time"
 
 	"golang.org/x/net/html"
 
 	"github.com/titpetric/vuego/internal/helpers"
-	"github.com/titpetric/vuego/internal/parser"
 	"github.com/titpetric/vuego/internal/reflect"
 )
 
-// templateCacheEntry stores cached template data with modification tracking.
-type templateCacheEntry struct {
-	dom         []*html.Node
-	frontMatter map[string]any
-	modTime     time.Time
-}
-
 // Vue is the main template renderer for .vuego templates.
 // After initialization, Vue is safe for concurrent use by multiple goroutines.
 type Vue struct {
@@ -30,10 +21,17 @@ type Vue struct {
 	funcMap    FuncMap
 	exprEval   *ExprEvaluator
 
-	// Template cache to avoid re-parsing the same template
+	// Template cache to avoid re-parsing the same template, shared by
+	// pages, includes and layouts. Guarded by templateMu, as is dependents.
 	templateCache map[string]*templateCacheEntry
 	templateMu    sync.RWMutex
 
+	// dependents maps a template to the cached templates that include it
+	dependents map[string]map[string]struct{}
+
+	// Parsed directive values and interpolations, filled by compile
+	compiled *compileCache
+
 	// Custom node processors for post-processing rendered DOM
 	nodeProcessors []NodeProcessor
 
@@ -44,6 +42,24 @@ type Vue struct {
 	// initialData is pre-loaded data from WithData() that seeds the template stack.
 	// This is equivalent to calling Fill() on the base template before any New()/Load().
 	initialData map[string]any
+
+	// strict enables strict mode, see WithStrict
+	strict bool
+
+	// goComponents are the components implemented in Go by tag name, see RegisterGoComponent
+	goComponents map[string]GoComponent
+
+	// directives are the custom directives by name, see RegisterDirective
+	directives map[string]Directive
+
+	// fragments stores the fragments rendered by v-cache, see WithFragmentCache
+	fragments FragmentCache
+
+	// limits bounds the resources of a render, see WithLimits
+	limits *Limits
+
+	// sanitizePolicies are the named policies of the sanitize filter, see WithSanitizePolicy
+	sanitizePolicies map[string]*SanitizePolicy
 }
 
 // NewVue creates a new Vue backed by the given filesystem.
@@ -55,7 +71,10 @@ func NewVue(templateFS fs.FS) *Vue {
 		renderer:      NewRenderer(),
 		exprEval:      NewExprEvaluator(),
 		templateCache: make(map[string]*templateCacheEntry),
+		dependents:    make(map[string]map[string]struct{}),
+		compiled:      newCompileCache(),
 		componentMap:  make(map[string]string),
+		fragments:     NewMemoryFragmentCache(DefaultFragmentCacheSize),
 	}
 	v.funcMap = v.DefaultFuncMap()
 	return v
@@ -71,26 +90,48 @@ func (v *Vue) Funcs(funcMap FuncMap) *Vue {
 }
 
 // renderNodesWithContext is an internal method that evaluates and renders nodes with a pre-configured context.
+// The nodes must be compiled; evaluation reads them without modification and builds a new output tree.
 func (v *Vue) renderNodesWithContext(ctx VueContext, w io.Writer, nodes []*html.Node) error {
-	nodeCopy := make([]*html.Node, 0, len(nodes))
-	for i := 0; i < len(nodes); i++ {
-		nodeCopy = append(nodeCopy, helpers.DeepCloneNode(nodes[i]))
+	result, err := v.evaluateNodes(ctx, nodes)
+	if err != nil {
+		return err
 	}
+	return v.renderResult(ctx, w, result)
+}
 
-	if err := v.preProcessNodes(ctx, nodeCopy); err != nil {
-		return err
+// evaluateNodes runs the pre-processors and evaluates compiled nodes into a new output tree.
+func (v *Vue) evaluateNodes(ctx VueContext, nodes []*html.Node) ([]*html.Node, error) {
+	// Pre-processors may rewrite the tree, so they get a private copy of the compiled nodes.
+	if len(ctx.Processors) > 0 {
+		nodeCopy := make([]*html.Node, 0, len(nodes))
+		ctx.sources = make(map[*html.Node]*html.Node)
+		for i := 0; i < len(nodes); i++ {
+			nodeCopy = append(nodeCopy, helpers.DeepCloneNode(nodes[i]))
+			mapSources(ctx.sources, nodeCopy[i], nodes[i])
+		}
+
+		if err := v.preProcessNodes(ctx, nodeCopy); err != nil {
+			return nil, err
+		}
+		nodes = nodeCopy
 	}
 
-	result, err := v.evaluate(ctx, nodeCopy, 0)
+	return v.evaluate(ctx, nodes, 0)
+}
+
+// renderResult moves teleported content, runs the post-processors over evaluated
+// nodes, scopes styles and serializes them to w.
+func (v *Vue) renderResult(ctx VueContext, w io.Writer, result []*html.Node) error {
+	result, err := v.teleportNodes(ctx, result)
 	if err != nil {
 		return err
 	}
-
 	if err := v.postProcessNodes(ctx, result); err != nil {
 		return err
 	}
+	result = scopeStyles(result)
 
-	return v.render(w, result)
+	return v.renderer.Render(ctx.Context(), v.limitOutput(ctx, w), result)
 }
 
 // toMapData converts any value to map[string]any for use as template context.
@@ -116,127 +157,73 @@ func toMapData(data any) map[string]any {
 // Front-matter data in the template is authoritative and overrides passed data.
 // Render is safe to call concurrently from multiple goroutines.
 func (v *Vue) Render(ctx context.Context, w io.Writer, filename string, data any) error {
-	frontMatter, dom, err := v.loadCachedWithFrontMatter(filename)
+	return v.renderStack(ctx, w, filename, NewStackWithData(toMapData(data), data))
+}
+
+// renderStack renders a full-page template file with the variables of stack.
+// Front-matter data is set in the top-most scope of stack, as it is authoritative.
+func (v *Vue) renderStack(ctx context.Context, w io.Writer, filename string, stack *Stack) error {
+	entry, err := v.loadCached(filename, true)
 	if err != nil {
 		return err
 	}
 
-	// Merge front-matter data into the provided data (front-matter is authoritative)
-	dataMap := toMapData(data)
-	for k, v := range frontMatter {
-		dataMap[k] = v
+	for k, v := range entry.frontMatter {
+		stack.Set(k, v)
 	}
 
-	// Create context for v-once attribute tracking
 	vueCtx := NewVueContext(ctx, filename, &VueContextOptions{
-		Stack:      NewStackWithData(dataMap, data),
+		Stack:      stack,
 		Processors: v.nodeProcessors,
-	})
-
-	// Assign unique IDs to all v-once elements for tracking across deep clones
-	for _, node := range dom {
-		assignSeenAttrs(&vueCtx, node)
-	}
+	}).withCompiled(entry.compiled)
 
 	// Use renderNodesWithContext with pre-configured context
-	return v.renderNodesWithContext(vueCtx, w, dom)
+	return v.renderNodesWithContext(vueCtx, w, entry.dom)
 }
 
-// loadCachedWithFrontMatter returns cached template nodes and front-matter data, or loads and caches them.
-// The cache stores DOM nodes, front-matter, and file modification time for invalidation.
-func (v *Vue) loadCachedWithFrontMatter(filename string) (map[string]any, []*html.Node, error) {
-	// Get current file modification time
-	var currentModTime time.Time
-	if v.templateFS != nil {
-		if info, err := fs.Stat(v.templateFS, filename); err == nil {
-			currentModTime = info.ModTime()
-		}
-	}
-
-	v.templateMu.RLock()
-	cached, ok := v.templateCache[filename]
-	if ok && (currentModTime.IsZero() || cached.modTime.Equal(currentModTime)) {
-		// Cache hit and file hasn't changed (or we can't check mtime)
-		v.templateMu.RUnlock()
-		return cached.frontMatter, cached.dom, nil
-	}
-	v.templateMu.RUnlock()
-
-	// Cache miss or file changed - reload
-	frontMatter, templateBytes, err := v.loader.loadFragment(filename)
+// evaluateFile evaluates a template file against stack and returns the output nodes without
+// post-processing or serializing them. The node processors of ctx are reused, so a chain of
+// layouts shares one set of processor instances. Front-matter data is set in
+// the top-most scope of stack, as it is authoritative.
+func (v *Vue) evaluateFile(ctx VueContext, filename string, stack *Stack) ([]*html.Node, error) {
+	entry, err := v.loadCached(filename, true)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	dom, err := parser.ParseTemplateBytes(templateBytes)
-	if err != nil {
-		return nil, nil, err
+	for k, v := range entry.frontMatter {
+		stack.Set(k, v)
 	}
 
-	v.templateMu.Lock()
-	v.templateCache[filename] = &templateCacheEntry{
-		dom:         dom,
-		frontMatter: frontMatter,
-		modTime:     currentModTime,
-	}
-	v.templateMu.Unlock()
+	fileCtx := NewVueContext(ctx.ctx, filename, &VueContextOptions{
+		Stack: stack,
+	}).withCompiled(entry.compiled)
+	fileCtx.Processors = ctx.Processors
+	fileCtx.budget = ctx.budget
 
-	return frontMatter, dom, nil
-}
-
-// assignSeenAttrs recursively assigns unique IDs to all v-once elements in the tree
-func assignSeenAttrs(ctx *VueContext, node *html.Node) {
-	if node.Type == html.ElementNode {
-		if helpers.HasAttr(node, "v-once") {
-			id := ctx.nextSeenID()
-			helpers.SetAttr(node, "v-once-id", id)
-		}
-	}
-	for c := node.FirstChild; c != nil; c = c.NextSibling {
-		assignSeenAttrs(ctx, c)
-	}
+	return v.evaluateNodes(fileCtx, entry.dom)
 }
 
 // RenderFragment processes a template fragment file and writes the output to w.
 // Front-matter data in the template is authoritative and overrides passed data.
 // RenderFragment is safe to call concurrently from multiple goroutines.
 func (v *Vue) RenderFragment(ctx context.Context, w io.Writer, filename string, data any) error {
-	frontMatter, templateBytes, err := v.loader.loadFragment(filename)
-	if err != nil {
-		return err
-	}
-
-	dom, err := parser.ParseTemplateBytes(templateBytes)
+	entry, err := v.loadCached(filename, true)
 	if err != nil {
 		return err
 	}
 
 	// Merge front-matter data into the provided data (front-matter is authoritative)
 	dataMap := toMapData(data)
-	for k, v := range frontMatter {
+	for k, v := range entry.frontMatter {
 		dataMap[k] = v
 	}
 
-	// Create context for v-once attribute tracking
 	vueCtx := NewVueContext(ctx, filename, &VueContextOptions{
 		Stack:      NewStackWithData(dataMap, data),
 		Processors: v.nodeProcessors,
-	})
-
-	// Assign unique IDs to all v-once elements for tracking across deep clones
-	for _, node := range dom {
-		assignSeenAttrs(&vueCtx, node)
-	}
+	}).withCompiled(entry.compiled)
 
 	// Use RenderNodes with pre-configured context
-	return v.renderNodesWithContext(vueCtx, w, dom)
-}
-
-func (v *Vue) render(w io.Writer, nodes []*html.Node) error {
-	for _, node := range nodes {
-		if err := renderNode(w, node, 0); err != nil {
-			return err
-		}
-	}
-	return nil
+	return v.renderNodesWithContext(vueCtx, w, entry.dom)
 }
@@ -0,0 +1,163 @@
+package vuego
+
+import (
+	"io/fs"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/titpetric/vuego/internal/helpers"
+	"github.com/titpetric/vuego/internal/parser"
+)
+
+// templateCacheEntry stores a compiled template with modification tracking.
+// The dom is shared between concurrent renders and must not be modified.
+type templateCacheEntry struct {
+	dom         []*html.Node
+	frontMatter map[string]any
+	modTime     time.Time
+	// compiled holds the loop bodies and source positions of dom.
+	compiled *compiledTemplate
+
+	// dependencies lists the templates referenced by include attributes and component tags.
+	dependencies []string
+
+	// props are the props declared by the template as a component, see propDeclaration,
+	// and propsErr is the error of an invalid declaration.
+	props    []*propSpec
+	propsErr error
+	// declared holds the names of the declared props, including :required ones.
+	declared map[string]bool
+}
+
+// loadCachedWithFrontMatter returns compiled template nodes and front-matter data, or loads and caches them.
+// The cache stores compiled DOM nodes, front-matter, and file modification time for invalidation.
+// Pages, includes and layouts all go through this cache.
+func (v *Vue) loadCachedWithFrontMatter(filename string) (map[string]any, []*html.Node, error) {
+	entry, err := v.loadCached(filename, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entry.frontMatter, entry.dom, nil
+}
+
+// loadInclude returns an included template from the cache. A template included
+// several times in one render, e.g. from a v-for, is checked for changes once.
+func (v *Vue) loadInclude(ctx VueContext, filename string) (*templateCacheEntry, error) {
+	check := !ctx.checked[filename]
+	if check && ctx.checked != nil {
+		ctx.checked[filename] = true
+	}
+	return v.loadCached(filename, check)
+}
+
+// loadCached returns a template from the cache, loading it on a miss.
+// With check set, a cached template is reloaded if its file has changed.
+func (v *Vue) loadCached(filename string, check bool) (*templateCacheEntry, error) {
+	// Get current file modification time
+	var currentModTime time.Time
+	if check && v.templateFS != nil {
+		if info, err := fs.Stat(v.templateFS, filename); err == nil {
+			currentModTime = info.ModTime()
+		}
+	}
+
+	v.templateMu.RLock()
+	cached, ok := v.templateCache[filename]
+	if ok && (currentModTime.IsZero() || cached.modTime.Equal(currentModTime)) {
+		// Cache hit and file hasn't changed (or we can't check mtime)
+		v.templateMu.RUnlock()
+		return cached, nil
+	}
+	v.templateMu.RUnlock()
+
+	// Cache miss or file changed - reload
+	frontMatter, templateBytes, lineOffset, err := v.loader.loadFragment(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	dom, positions, err := parser.ParseTemplateBytesWithPositions(templateBytes, lineOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.compile(dom); err != nil {
+		return nil, err
+	}
+	v.scopeTemplate(filename, dom)
+	compiled := newCompiledTemplate(filename, dom, positions)
+
+	props, propsErr := v.propDeclaration(frontMatter, dom)
+	declared := declaredProps(dom)
+	for _, spec := range props {
+		declared[spec.name] = true
+	}
+
+	entry := &templateCacheEntry{
+		dom:          dom,
+		frontMatter:  frontMatter,
+		modTime:      currentModTime,
+		compiled:     compiled,
+		dependencies: templateDependencies(dom),
+		props:        props,
+		propsErr:     propsErr,
+		declared:     declared,
+	}
+
+	v.templateMu.Lock()
+	// A changed file invalidates every cached template that includes it
+	v.invalidate(filename)
+	v.templateCache[filename] = entry
+	for _, dep := range entry.dependencies {
+		if v.dependents[dep] == nil {
+			v.dependents[dep] = make(map[string]struct{})
+		}
+		v.dependents[dep][filename] = struct{}{}
+	}
+	v.templateMu.Unlock()
+
+	return entry, nil
+}
+
+// invalidate removes filename and, transitively, every template that depends on it from the cache.
+// The caller must hold templateMu for writing.
+func (v *Vue) invalidate(filename string) {
+	entry, ok := v.templateCache[filename]
+	if !ok {
+		return
+	}
+	delete(v.templateCache, filename)
+
+	for _, dep := range entry.dependencies {
+		delete(v.dependents[dep], filename)
+	}
+	for dependent := range v.dependents[filename] {
+		v.invalidate(dependent)
+	}
+}
+
+// templateDependencies returns the unique include targets referenced in a compiled DOM.
+// Component tags have been rewritten to includes by compile, so they are covered as well.
+func templateDependencies(nodes []*html.Node) []string {
+	var result []string
+	seen := make(map[string]bool)
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "template" {
+			if name := helpers.GetAttr(n, "include"); name != "" && !seen[name] {
+				seen[name] = true
+				result = append(result, name)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	for _, node := range nodes {
+		walk(node)
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package vuego_test
+
+import (
+	"bytes"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/titpetric/vuego"
+	"github.com/titpetric/vuego/testing/assert"
+)
+
+// readCountingFS counts ReadFile calls per filename.
+type readCountingFS struct {
+	fstest.MapFS
+	reads map[string]int
+}
+
+func (c *readCountingFS) ReadFile(name string) ([]byte, error) {
+	c.reads[name]++
+	return fs.ReadFile(c.MapFS, name)
+}
+
+func TestVue_Render_IncludesAreCached(t *testing.T) {
+	templateFS := &readCountingFS{
+		MapFS: fstest.MapFS{
+			"page.vuego": &fstest.MapFile{Data: []byte(`<ul><li v-for="row in rows"><row-item :name="row"></row-item></li></ul>`)},
+			"row.vuego":  &fstest.MapFile{Data: []byte(`<span>{{ name }}</span>`)},
+		},
+		reads: map[string]int{},
+	}
+
+	vue := vuego.NewVue(templateFS).RegisterComponent("row-item", "row.vuego")
+	data := map[string]any{"rows": []string{"a", "b", "c", "d"}}
+
+	for range 3 {
+		var buf bytes.Buffer
+		assert.NoError(t, vue.Render(t.Context(), &buf, "page.vuego", data))
+		assert.EqualHTML(t, []byte(`<ul><li><span>a</span></li><li><span>b</span></li><li><span>c</span></li><li><span>d</span></li></ul>`), buf.Bytes(), nil, nil)
+	}
+
+	assert.Equal(t, 1, templateFS.reads["page.vuego"])
+	assert.Equal(t, 1, templateFS.reads["row.vuego"])
+}
+
+func TestVue_Render_IncludeChangeInvalidatesDependents(t *testing.T) {
+	now := time.Now()
+	templateFS := &readCountingFS{
+		MapFS: fstest.MapFS{
+			"page.vuego":   &fstest.MapFile{Data: []byte(`<div><template include="card.vuego"></template></div>`), ModTime: now},
+			"card.vuego":   &fstest.MapFile{Data: []byte(`<p><template include="footer.vuego"></template></p>`), ModTime: now},
+			"footer.vuego": &fstest.MapFile{Data: []byte(`old`), ModTime: now},
+		},
+		reads: map[string]int{},
+	}
+
+	vue := vuego.NewVue(templateFS)
+
+	var buf bytes.Buffer
+	assert.NoError(t, vue.RenderFragment(t.Context(), &buf, "page.vuego", nil))
+	assert.EqualHTML(t, []byte(`<div><p>old</p></div>`), buf.Bytes(), nil, nil)
+
+	templateFS.MapFS["footer.vuego"] = &fstest.MapFile{Data: []byte(`new`), ModTime: now.Add(time.Second)}
+
+	buf.Reset()
+	assert.NoError(t, vue.RenderFragment(t.Context(), &buf, "page.vuego", nil))
+	assert.EqualHTML(t, []byte(`<div><p>new</p></div>`), buf.Bytes(), nil, nil)
+
+	// The changed footer invalidated card.vuego and page.vuego transitively,
+	// so the next render reloads both of them.
+	buf.Reset()
+	assert.NoError(t, vue.RenderFragment(t.Context(), &buf, "page.vuego", nil))
+	assert.Equal(t, 2, templateFS.reads["page.vuego"])
+	assert.Equal(t, 2, templateFS.reads["card.vuego"])
+	assert.Equal(t, 2, templateFS.reads["footer.vuego"])
+}
+
+// statCountingFS counts Stat calls per filename.
+type statCountingFS struct {
+	fstest.MapFS
+	stats map[string]int
+}
+
+func (c *statCountingFS) Stat(name string) (fs.FileInfo, error) {
+	c.stats[name]++
+	return c.MapFS.Stat(name)
+}
+
+func TestVue_Render_IncludeCheckedOncePerRender(t *testing.T) {
+	templateFS := &statCountingFS{
+		MapFS: fstest.MapFS{
+			"page.vuego": &fstest.MapFile{Data: []byte(`<ul><li v-for="row in rows"><template include="row.vuego" :name="row"></template></li></ul>`)},
+			"row.vuego":  &fstest.MapFile{Data: []byte(`<span>{{ name }}</span>`)},
+		},
+		stats: map[string]int{},
+	}
+
+	vue := vuego.NewVue(templateFS)
+	data := map[string]any{"rows": []string{"a", "b", "c", "d"}}
+
+	for range 2 {
+		var buf bytes.Buffer
+		assert.NoError(t, vue.RenderFragment(t.Context(), &buf, "page.vuego", data))
+	}
+
+	assert.Equal(t, 2, templateFS.stats["row.vuego"])
+}
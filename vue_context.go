@@ -2,11 +2,12 @@ package vuego
 
 import (
 	"context"
+	"fmt"
 	"path"
 	"reflect"
 	"strings"
 
-	"github.com/titpetric/vuego/internal/ulid"
+	"golang.org/x/net/html"
 )
 
 // VueContext carries template inclusion context and request-scoped state used during evaluation.
@@ -34,8 +35,25 @@ type VueContext struct {
 	// v-once element tracking for deep clones
 	seen map[string]bool
 
+	// checked holds the included templates already checked for changes in this render
+	checked map[string]bool
+
+	// sources maps pre-processed copies of compiled nodes back to the
+	// compiled nodes, so render errors can be located in the template source.
+	sources map[*html.Node]*html.Node
+
+	// compiled holds the compiled templates being evaluated, innermost last,
+	// for the loop bodies and source positions of their nodes.
+	compiled []*compiledTemplate
+
 	// SlotScope contains slot content for the current component.
 	SlotScope *SlotScope
+
+	// cacheNode is the v-cache element being rendered for the fragment cache.
+	cacheNode *html.Node
+
+	// budget counts the resources used by the render, see Limits.
+	budget *renderBudget
 }
 
 // VueContextOptions holds configurable options for a new VueContext.
@@ -56,6 +74,8 @@ func NewVueContext(ctx context.Context, fromFilename string, options *VueContext
 		TemplateStack: []string{fromFilename},
 		TagStack:      []string{},
 		seen:          make(map[string]bool),
+		checked:       make(map[string]bool),
+		budget:        &renderBudget{},
 	}
 	for _, v := range options.Processors {
 		result.Processors = append(result.Processors, v.New())
@@ -77,11 +97,22 @@ func (ctx VueContext) WithTemplate(filename string) VueContext {
 		TemplateStack: newStack,
 		TagStack:      ctx.TagStack, // Share the same tag stack
 		seen:          ctx.seen,     // Share the v-once tracking map
+		checked:       ctx.checked,
+		sources:       ctx.sources,
+		compiled:      ctx.compiled,
 		Processors:    ctx.Processors,
 		SlotScope:     ctx.SlotScope, // Share the slot scope
+		budget:        ctx.budget,
 	}
 }
 
+// withCompiled returns a copy of the context that evaluates the nodes of c,
+// within the templates already evaluated by ctx.
+func (ctx VueContext) withCompiled(c *compiledTemplate) VueContext {
+	ctx.compiled = append(ctx.compiled[:len(ctx.compiled):len(ctx.compiled)], c)
+	return ctx
+}
+
 // FormatTemplateChain returns the template inclusion chain formatted for error messages.
 func (ctx VueContext) FormatTemplateChain() string {
 	if len(ctx.TemplateStack) <= 1 {
@@ -115,8 +146,24 @@ func (ctx VueContext) Context() context.Context {
 	return ctx.ctx
 }
 
+// Err returns the error of the context.Context once it is cancelled or its
+// deadline is exceeded, wrapped with the template chain. Evaluation checks it
+// regularly, so a render stops soon after the client goes away.
+func (ctx VueContext) Err() error {
+	if ctx.ctx == nil {
+		return nil
+	}
+	select {
+	case <-ctx.ctx.Done():
+		return fmt.Errorf("in %s: %w", ctx.FormatTemplateChain(), ctx.ctx.Err())
+	default:
+		return nil
+	}
+}
+
 // ExprEnv returns the env map for expr evaluation, wrapping any functions whose
 // first parameter is context.Context so the context is injected automatically.
+// It flattens the stack; expressions are evaluated against exprEnv instead.
 func (ctx VueContext) ExprEnv() map[string]any {
 	env := ctx.stack.EnvMap()
 	ctx.bindContextFuncs(env)
@@ -126,25 +173,52 @@ func (ctx VueContext) ExprEnv() map[string]any {
 // bindContextFuncs wraps function values in env whose first parameter is
 // context.Context, binding ctx.ctx so expr can call them without arguments.
 func (ctx VueContext) bindContextFuncs(env map[string]any) {
-	contextType := reflect.TypeOf((*context.Context)(nil)).Elem()
 	for k, v := range env {
-		rv := reflect.ValueOf(v)
-		if rv.Kind() != reflect.Func {
-			continue
-		}
-		ft := rv.Type()
-		if ft.NumIn() == 0 {
-			continue
-		}
-		if ft.In(0) != contextType {
-			continue
-		}
-		env[k] = ctx.wrapContextFunc(rv, ft)
+		env[k] = bindContextFunc(ctx.ctx, v)
 	}
 }
 
-// wrapContextFunc returns a new function that prepends ctx.ctx to the call.
-func (ctx VueContext) wrapContextFunc(fn reflect.Value, ft reflect.Type) any {
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// bindContextFunc wraps v if it is a function whose first parameter is
+// context.Context, see bindContextFuncs. Other values are returned as is.
+func bindContextFunc(ctx context.Context, v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Func {
+		return v
+	}
+	ft := rv.Type()
+	if ft.NumIn() == 0 || ft.In(0) != contextType {
+		return v
+	}
+	return wrapContextFunc(ctx, rv, ft)
+}
+
+// exprEnv is the environment expressions are evaluated against. Variables
+// are looked up in the scope chain of the stack when they are accessed, see
+// memberPatcher, so the stack isn't flattened for every expression.
+type exprEnv struct {
+	stack *Stack
+	ctx   context.Context
+}
+
+// exprEnv returns the expression environment of the current scope.
+func (ctx VueContext) exprEnv() *exprEnv {
+	return &exprEnv{stack: ctx.stack, ctx: ctx.ctx}
+}
+
+// Lookup returns a variable from the stack, binding functions that take a
+// context.Context to the render context.
+func (e *exprEnv) Lookup(name string) (any, bool) {
+	val, ok := e.stack.Lookup(name)
+	if !ok {
+		return nil, false
+	}
+	return bindContextFunc(e.ctx, val), true
+}
+
+// wrapContextFunc returns a new function that prepends ctx to the call.
+func wrapContextFunc(ctx context.Context, fn reflect.Value, ft reflect.Type) any {
 	// Build the wrapped function type: same signature minus the first context.Context param.
 	numIn := ft.NumIn()
 	inTypes := make([]reflect.Type, numIn-1)
@@ -158,7 +232,7 @@ func (ctx VueContext) wrapContextFunc(fn reflect.Value, ft reflect.Type) any {
 	wrappedType := reflect.FuncOf(inTypes, outTypes, ft.IsVariadic())
 	wrapped := reflect.MakeFunc(wrappedType, func(args []reflect.Value) []reflect.Value {
 		fullArgs := make([]reflect.Value, 0, len(args)+1)
-		fullArgs = append(fullArgs, reflect.ValueOf(ctx.ctx))
+		fullArgs = append(fullArgs, reflect.ValueOf(ctx))
 		fullArgs = append(fullArgs, args...)
 		return fn.Call(fullArgs)
 	})
@@ -170,8 +244,3 @@ func (ctx VueContext) wrapContextFunc(fn reflect.Value, ft reflect.Type) any {
 func (ctx VueContext) Stack() *Stack {
 	return ctx.stack
 }
-
-// nextSeenID returns a unique ID for tracking v-once elements across deep clones.
-func (ctx *VueContext) nextSeenID() string {
-	return ulid.String()
-}